@@ -19,30 +19,56 @@ type Alumni struct {
 	Alamat     *string    `bson:"alamat" json:"alamat"`
 	CreatedAt  time.Time  `bson:"created_at" json:"created_at"`
 	UpdatedAt  time.Time  `bson:"updated_at" json:"updated_at"`
+	IsDeleted  bool                `bson:"is_deleted" json:"is_deleted"`
+	DeletedAt  *time.Time          `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy  *primitive.ObjectID `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
+}
+
+// AlumniTrash -> alumni di trash beserta jumlah pekerjaan yang ikut terhapus bersamanya
+type AlumniTrash struct {
+	ID              primitive.ObjectID  `bson:"_id" json:"id"`
+	NIM             string              `bson:"nim" json:"nim"`
+	Nama            string              `bson:"nama" json:"nama"`
+	Jurusan         string              `bson:"jurusan" json:"jurusan"`
+	DeletedAt       *time.Time          `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy       *primitive.ObjectID `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
+	JumlahPekerjaan int                 `bson:"jumlah_pekerjaan" json:"jumlah_pekerjaan"`
+}
+
+// AlumniPurgeResult -> data yang ikut terhapus permanen bersama alumni
+type AlumniPurgeResult struct {
+	Pekerjaan int64  `json:"pekerjaan"`
+	Files     []File `json:"-"`
 }
 
 type AlumniWithSalary struct {
-    AlumniID       int    `bson:"alumni_id" json:"alumni_id"`
+    AlumniID       primitive.ObjectID `bson:"alumni_id" json:"alumni_id"`
     NamaAlumni     string `bson:"nama_alumni" json:"nama_alumni"`
     NIM            string `bson:"nim" json:"nim"`
     Jurusan        string `bson:"jurusan" json:"jurusan"`
-    Angkatan       int    `bson:"angkatan"json:"angkatan"`
+    Angkatan       int    `bson:"angkatan" json:"angkatan"`
     NamaPerusahaan string `bson:"nama_perusahaan" json:"nama_perusahaan"`
     PosisiJabatan  string `bson:"posisi_jabatan" json:"posisi_jabatan"`
-    GajiRange      string `bson:"gaji_range" json:"gaji_range"`
+    GajiRange      *GajiRange `bson:"gaji_range" json:"gaji_range"`
 }
 
 type AlumniWithYear struct {
-    AlumniID       int    `bson:"alumni_id" json:"alumni_id"`
+    AlumniID       primitive.ObjectID `bson:"alumni_id" json:"alumni_id"`
     NamaAlumni     string `bson:"nama_alumni" json:"nama_alumni"`
     NIM            string `bson:"nim" json:"nim"`
     Jurusan        string `bson:"jurusan" json:"jurusan"`
     Angkatan       int    `bson:"angkatan" json:"angkatan"`
     TahunLulus     int    `bson:"tahun_lulus" json:"tahun_lulus"`
-    TanggalMulaiKerja       string    `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja"`
+    TanggalMulaiKerja       time.Time `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja"`
     NamaPerusahaan string `bson:"nama_perusahaan" json:"nama_perusahaan"`
     PosisiJabatan  string `bson:"posisi_jabatan" json:"posisi_jabatan"`
-    GajiRange      string `bson:"gaji_range" json:"gaji_range"`
+    GajiRange      *GajiRange `bson:"gaji_range" json:"gaji_range"`
 }
 
-
+// UpdateMyProfileRequest -> field yang boleh diubah alumni pada profilnya sendiri.
+// no_telepon dan alamat berisi string kosong akan dikosongkan.
+type UpdateMyProfileRequest struct {
+	Email  *string `json:"email" example:"budi@mail.com"`
+	NoTelp *string `json:"no_telepon" example:"081234567890"`
+	Alamat *string `json:"alamat" example:"Jl. Airlangga No. 4, Surabaya"`
+}
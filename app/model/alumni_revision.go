@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RevisionBaseline -> revisi pertama yang menyimpan kondisi alumni sebelum perubahan pertama
+// yang tercatat, untuk data yang dibuat sebelum riwayat revisi ada atau lewat registrasi
+const RevisionBaseline = "baseline"
+
+// AlumniRevision -> snapshot lengkap data alumni setelah satu perubahan pada koleksi
+// alumni_revisions. Version berurutan per alumni mulai dari 1.
+type AlumniRevision struct {
+	ID           primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	AlumniID     primitive.ObjectID  `bson:"alumni_id" json:"alumni_id"`
+	Version      int                 `bson:"version" json:"version"`
+	Action       string              `bson:"action" json:"action"`
+	Changes      []string            `bson:"changes,omitempty" json:"changes,omitempty"`
+	RestoredFrom int                 `bson:"restored_from,omitempty" json:"restored_from,omitempty"`
+	Snapshot     Alumni              `bson:"snapshot" json:"snapshot"`
+	ActorID      *primitive.ObjectID `bson:"actor_id,omitempty" json:"actor_id,omitempty"`
+	Actor        string              `bson:"actor" json:"actor"`
+	CreatedAt    time.Time           `bson:"created_at" json:"created_at"`
+}
+
+// AlumniRevisionDiff -> perbedaan field antara dua revisi alumni
+type AlumniRevisionDiff struct {
+	AlumniID primitive.ObjectID     `json:"alumni_id"`
+	From     int                    `json:"from"`
+	To       int                    `json:"to"`
+	Changes  map[string]AuditChange `json:"changes"`
+}
+
+type AlumniRevisionListResponse struct {
+	Message string           `json:"message"`
+	Success bool             `json:"success"`
+	Data    []AlumniRevision `json:"data"`
+	Meta    MetaInfo         `json:"meta"`
+}
+
+type AlumniRevisionDiffResponse struct {
+	Message string             `json:"message"`
+	Success bool               `json:"success"`
+	Data    AlumniRevisionDiff `json:"data"`
+}
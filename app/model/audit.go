@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Aksi yang dicatat pada audit log
+const (
+	AuditCreate     = "create"
+	AuditUpdate     = "update"
+	AuditSoftDelete = "soft_delete"
+	AuditRestore    = "restore"
+	AuditHardDelete = "hard_delete"
+	AuditUpload     = "upload"
+	AuditImport     = "import"
+	AuditRollback   = "rollback"
+)
+
+// Jenis entitas pada audit log
+const (
+	AuditEntityAlumni      = "alumni"
+	AuditEntityPekerjaan   = "pekerjaan"
+	AuditEntityFile        = "file"
+	AuditEntityUser        = "user"
+	AuditEntityClaimReview = "claim_review"
+)
+
+// AuditChange -> nilai sebuah field sebelum dan sesudah perubahan
+type AuditChange struct {
+	Before interface{} `bson:"before,omitempty" json:"before,omitempty"`
+	After  interface{} `bson:"after,omitempty" json:"after,omitempty"`
+}
+
+// AuditLog -> satu perubahan data pada koleksi audit_logs. Changes hanya memuat field
+// yang berubah; untuk create semua field berada di After, untuk hard delete di Before.
+type AuditLog struct {
+	ID         primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	ActorID    *primitive.ObjectID    `bson:"actor_id,omitempty" json:"actor_id,omitempty"`
+	Actor      string                 `bson:"actor" json:"actor"`
+	Role       string                 `bson:"role" json:"role"`
+	Action     string                 `bson:"action" json:"action"`
+	EntityType string                 `bson:"entity_type" json:"entity_type"`
+	EntityID   string                 `bson:"entity_id" json:"entity_id"`
+	Changes    map[string]AuditChange `bson:"changes,omitempty" json:"changes,omitempty"`
+	IP         string                 `bson:"ip" json:"ip"`
+	Timestamp  time.Time              `bson:"timestamp" json:"timestamp"`
+}
+
+// AuditFilter -> filter query audit log; field kosong tidak dipakai
+type AuditFilter struct {
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   string
+	From       *time.Time
+	To         *time.Time
+}
+
+type AuditLogResponse struct {
+	Message string     `json:"message"`
+	Success bool       `json:"success"`
+	Data    []AuditLog `json:"data"`
+	Meta    MetaInfo   `json:"meta"`
+}
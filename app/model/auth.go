@@ -12,6 +12,8 @@ type User struct {
     PasswordHash    string              `bson:"password_hash" json:"password_hash"` 
     Email           string              `bson:"email" json:"email"` 
     Role            string              `bson:"role" json:"role"` 
+    // Jurusan -> jurusan yang boleh diakses user ber-role staff
+    Jurusan         []string            `bson:"jurusan,omitempty" json:"jurusan,omitempty"`
     CreatedAt       time.Time           `bson:"created_at" json:"created_at"` 
 } 
  
@@ -22,18 +24,87 @@ type LoginRequest struct {
 type LoginResponse struct { 
 	User            User                `bson:"user" json:"user"` 
 	Token           string              `bson:"token" json:"token"` 
+	RefreshToken    string              `bson:"refresh_token" json:"refresh_token"`
+	ExpiresIn       int64               `bson:"expires_in" json:"expires_in" example:"900"`
 } 
+// JWTClaims -> RegisteredClaims.ID dipakai sebagai JTI untuk pencabutan token
 type JWTClaims struct { 
 	UserID          primitive.ObjectID  `bson:"_id,omitempty" json:"user_id"`
 	Username        string              `bson:"username" json:"username"` 
 	Role            string              `bson:"role" json:"role"` 
+	Jurusan         []string            `bson:"jurusan,omitempty" json:"jurusan,omitempty"`
 	jwt.RegisteredClaims 
 } 
 
+// RefreshToken -> sesi login yang disimpan di koleksi refresh_tokens
+type RefreshToken struct {
+	ID              primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID          primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	TokenHash       string              `bson:"token_hash" json:"-"`
+	AccessJTI       string              `bson:"access_jti" json:"-"`
+	AccessExpiresAt time.Time           `bson:"access_expires_at" json:"-"`
+	ExpiresAt       time.Time           `bson:"expires_at" json:"expires_at"`
+	CreatedAt       time.Time           `bson:"created_at" json:"created_at"`
+	RevokedAt       *time.Time          `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	ReplacedBy      *primitive.ObjectID `bson:"replaced_by,omitempty" json:"replaced_by,omitempty"`
+}
+
+type RefreshRequest struct {
+	RefreshToken    string              `json:"refresh_token"`
+}
+
+type LogoutRequest struct {
+	RefreshToken    string              `json:"refresh_token"`
+	All             bool                `json:"all"`
+}
+
+// Role user
+const (
+    RoleAdmin       = "admin"
+    RoleAlumni      = "alumni"
+    // RoleStaff -> staf fakultas, aksesnya dibatasi ke jurusan yang tercatat di akunnya
+    RoleStaff       = "staff"
+)
+
+// RegisterRequest -> registrasi mandiri, role hanya boleh kosong atau "alumni".
+// Field alumni dipakai untuk membuat profil alumni bersamaan dengan user.
+// Aturan validasi ada di service.ValidateRegisterRequest.
 type RegisterRequest struct {
-    Username        string              `bson:"username" json:"username" validate:"unique,required,min=3,max=50"`
-    Email           string              `bson:"email" json:"email" validate:"unique,required,email"`
-    Password        string              `bson:"password" json:"password" validate:"required,min=6"`
-    Role            string              `bson:"role" json:"role"` 
+    Username        string              `bson:"username" json:"username" example:"budi_santoso"`
+    Email           string              `bson:"email" json:"email" example:"budi@mail.com"`
+    Password        string              `bson:"password" json:"password" example:"Rahasia123"`
+    Role            string              `bson:"role" json:"role" example:"alumni"` 
+    NIM             string              `bson:"nim" json:"nim" example:"187221001"`
+    Nama            string              `bson:"nama" json:"nama" example:"Budi Santoso"`
+    Jurusan         string              `bson:"jurusan" json:"jurusan" example:"Informatika"`
+    Angkatan        int                 `bson:"angkatan" json:"angkatan" example:"2018"`
+    TahunLulus      int                 `bson:"tahun_lulus" json:"tahun_lulus" example:"2022"`
+    ClaimCode       string              `bson:"-" json:"claim_code,omitempty" example:"K7Q2-M9XD"`
+}
+
+// CreateUserRequest -> pembuatan user oleh admin, role boleh admin, alumni, atau staff.
+// Jurusan wajib diisi untuk staff dan tidak boleh diisi untuk role lain.
+type CreateUserRequest struct {
+    Username        string              `json:"username" example:"operator_ft"`
+    Email           string              `json:"email" example:"operator@unair.ac.id"`
+    Password        string              `json:"password" example:"Rahasia123"`
+    Role            string              `json:"role" example:"admin"`
+    Jurusan         []string            `json:"jurusan,omitempty" example:"Teknik Informatika"`
 }
 
+// JWK -> public key dalam format JSON Web Key (RFC 7517)
+type JWK struct {
+	Kty             string              `json:"kty" example:"RSA"`
+	Kid             string              `json:"kid"`
+	Use             string              `json:"use" example:"sig"`
+	Alg             string              `json:"alg" example:"RS256"`
+	N               string              `json:"n,omitempty"`
+	E               string              `json:"e,omitempty"`
+	Crv             string              `json:"crv,omitempty"`
+	X               string              `json:"x,omitempty"`
+}
+
+// JWKSet -> isi endpoint /.well-known/jwks.json
+type JWKSet struct {
+	Keys            []JWK               `json:"keys"`
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Alasan klaim NIM diteruskan ke admin
+const (
+	ClaimReasonSudahDiklaim = "nim_sudah_diklaim"
+	ClaimReasonDuplikat     = "nim_duplikat"
+)
+
+// Status pemeriksaan klaim NIM
+const (
+	ClaimReviewPending  = "pending"
+	ClaimReviewResolved = "resolved"
+	ClaimReviewRejected = "rejected"
+)
+
+// AlumniClaimCode -> kode klaim sekali pakai yang diterbitkan admin untuk satu data alumni.
+// Yang disimpan hanya hash kodenya.
+type AlumniClaimCode struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AlumniID  primitive.ObjectID `bson:"alumni_id" json:"alumni_id"`
+	CodeHash  string             `bson:"code_hash" json:"-"`
+	Attempts  int                `bson:"attempts" json:"attempts"`
+	CreatedBy string             `bson:"created_by" json:"created_by"`
+	ExpiresAt time.Time          `bson:"expires_at" json:"expires_at"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UsedAt    *time.Time         `bson:"used_at,omitempty" json:"used_at,omitempty"`
+}
+
+// ClaimCodeResponse -> kode klaim hanya ditampilkan sekali saat diterbitkan
+type ClaimCodeResponse struct {
+	AlumniID  primitive.ObjectID `json:"alumni_id"`
+	NIM       string             `json:"nim"`
+	Code      string             `json:"code" example:"K7Q2-M9XD"`
+	ExpiresAt time.Time          `json:"expires_at"`
+}
+
+// AlumniClaimReview -> registrasi dengan NIM bermasalah yang perlu diperiksa admin
+type AlumniClaimReview struct {
+	ID         primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	NIM        string               `bson:"nim" json:"nim"`
+	AlumniIDs  []primitive.ObjectID `bson:"alumni_ids" json:"alumni_ids"`
+	Reason     string               `bson:"reason" json:"reason" example:"nim_sudah_diklaim"`
+	Username   string               `bson:"username" json:"username"`
+	Email      string               `bson:"email" json:"email"`
+	Status     string               `bson:"status" json:"status" example:"pending"`
+	Catatan    string               `bson:"catatan,omitempty" json:"catatan,omitempty"`
+	ResolvedBy string               `bson:"resolved_by,omitempty" json:"resolved_by,omitempty"`
+	ResolvedAt *time.Time           `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+	CreatedAt  time.Time            `bson:"created_at" json:"created_at"`
+}
+
+// ResolveClaimReviewRequest -> keputusan admin atas klaim NIM
+type ResolveClaimReviewRequest struct {
+	Status  string `json:"status" example:"resolved"`
+	Catatan string `json:"catatan" example:"Data ganda sudah digabung"`
+}
+
+type ClaimReviewResponse struct {
+	Message string              `json:"message"`
+	Success bool                `json:"success" example:"true"`
+	Data    []AlumniClaimReview `json:"data"`
+	Meta    MetaInfo            `json:"meta"`
+}
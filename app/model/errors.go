@@ -0,0 +1,102 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorKind -> jenis error domain. Nilainya sekaligus kode error yang dibaca mesin pada
+// field error_code di ErrorResponse.
+type ErrorKind string
+
+const (
+	ErrKindValidation   ErrorKind = "VALIDATION_ERROR"
+	ErrKindUnauthorized ErrorKind = "UNAUTHORIZED"
+	ErrKindForbidden    ErrorKind = "FORBIDDEN"
+	ErrKindNotFound     ErrorKind = "NOT_FOUND"
+	ErrKindConflict     ErrorKind = "CONFLICT"
+	ErrKindInternal     ErrorKind = "INTERNAL_ERROR"
+)
+
+// FieldError -> detail validasi untuk satu field input
+type FieldError struct {
+	Field   string `json:"field" example:"email"`
+	Message string `json:"message" example:"format email tidak valid"`
+}
+
+// AppError -> error domain yang dikembalikan repository dan service. Error handler Fiber
+// memetakannya ke status HTTP dan ErrorResponse. Err menyimpan penyebab asli untuk log
+// dan tidak pernah dikirim ke client.
+type AppError struct {
+	Kind    ErrorKind
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *AppError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// Is membuat errors.Is cocok dengan AppError lain yang jenis dan pesannya sama, sehingga
+// AppError bisa dipakai sebagai sentinel
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Kind == e.Kind && t.Message == e.Message
+}
+
+// NewValidationError -> input tidak valid (400), opsional dengan detail per field
+func NewValidationError(message string, fields ...FieldError) *AppError {
+	return &AppError{Kind: ErrKindValidation, Message: message, Fields: fields}
+}
+
+// NewUnauthorizedError -> belum login atau token tidak valid (401)
+func NewUnauthorizedError(message string) *AppError {
+	return &AppError{Kind: ErrKindUnauthorized, Message: message}
+}
+
+// NewForbiddenError -> user tidak berhak atas aksi atau data (403)
+func NewForbiddenError(message string) *AppError {
+	return &AppError{Kind: ErrKindForbidden, Message: message}
+}
+
+// NewNotFoundError -> data tidak ditemukan (404)
+func NewNotFoundError(message string) *AppError {
+	return &AppError{Kind: ErrKindNotFound, Message: message}
+}
+
+// NewConflictError -> data bentrok dengan data yang sudah ada (409), opsional dengan field yang bentrok
+func NewConflictError(message string, fields ...FieldError) *AppError {
+	return &AppError{Kind: ErrKindConflict, Message: message, Fields: fields}
+}
+
+// NewInternalError -> kegagalan server (500). message dikirim ke client, err hanya dicatat di log.
+func NewInternalError(message string, err error) *AppError {
+	return &AppError{Kind: ErrKindInternal, Message: message, Err: err}
+}
+
+// WrapError meneruskan err apa adanya jika sudah berupa AppError (misalnya ID tidak valid
+// dari repository); error lain dibungkus sebagai error internal dengan message
+func WrapError(err error, message string) error {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return err
+	}
+	return NewInternalError(message, err)
+}
+
+// ErrorKindOf mengembalikan jenis AppError di dalam err, atau ErrKindInternal jika err bukan AppError
+func ErrorKindOf(err error) ErrorKind {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+	return ErrKindInternal
+}
@@ -0,0 +1,182 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GajiRange -> rentang gaji terstruktur (per bulan)
+type GajiRange struct {
+	Min      int64  `bson:"min" json:"min" example:"5000000"`
+	Max      int64  `bson:"max" json:"max" example:"10000000"`
+	MataUang string `bson:"mata_uang" json:"mata_uang" example:"IDR"`
+}
+
+// UnmarshalJSON menerima bentuk objek {"min","max","mata_uang"}, angka, maupun teks
+// bebas seperti "5-10 juta", "Rp 7.500.000", atau "10000000".
+func (g *GajiRange) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	switch v := raw.(type) {
+	case nil:
+		return nil
+	case string:
+		parsed, err := ParseGajiRange(v)
+		if err != nil {
+			return err
+		}
+		if parsed != nil {
+			*g = *parsed
+		}
+		return nil
+	case float64:
+		// angka harus bilangan bulat >= 0, sama seperti min/max pada bentuk objek
+		if v < 0 || v != math.Trunc(v) || v >= math.MaxInt64 {
+			return fmt.Errorf("gaji_range tidak valid: angka harus bilangan bulat >= 0")
+		}
+		*g = GajiRange{Min: int64(v), Max: int64(v), MataUang: "IDR"}
+		return nil
+	}
+
+	type plain GajiRange
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return fmt.Errorf("gaji_range tidak valid: %v", err)
+	}
+	if p.MataUang == "" {
+		p.MataUang = "IDR"
+	}
+	if p.Max == 0 {
+		p.Max = p.Min
+	}
+	if p.Min < 0 || p.Max < p.Min {
+		return fmt.Errorf("gaji_range tidak valid: min harus >= 0 dan <= max")
+	}
+	*g = GajiRange(p)
+	return nil
+}
+
+var (
+	gajiRangeSeparator = regexp.MustCompile(`\s*(?:-|–|s/d|sampai|hingga)\s*`)
+	gajiPartPattern    = regexp.MustCompile(`^([0-9][0-9.,]*)\s*([a-z]*)$`)
+)
+
+var gajiMultipliers = map[string]float64{
+	"":       1,
+	"rb":     1e3,
+	"ribu":   1e3,
+	"k":      1e3,
+	"jt":     1e6,
+	"juta":   1e6,
+	"m":      1e9,
+	"miliar": 1e9,
+}
+
+// ParseGajiRange mengubah teks gaji bebas menjadi GajiRange.
+// Teks kosong menghasilkan nil tanpa error.
+func ParseGajiRange(input string) (*GajiRange, error) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	if s == "" {
+		return nil, nil
+	}
+
+	mataUang := "IDR"
+	if strings.Contains(s, "usd") || strings.Contains(s, "$") {
+		mataUang = "USD"
+	}
+	for _, token := range []string{",-", ".-", "idr", "usd", "rp.", "rp", "$", "/bulan", "per bulan"} {
+		s = strings.ReplaceAll(s, token, "")
+	}
+	s = strings.TrimSpace(s)
+
+	parts := gajiRangeSeparator.Split(s, -1)
+	if len(parts) > 2 {
+		return nil, fmt.Errorf("format gaji tidak dikenali: %q", input)
+	}
+
+	values := make([]float64, len(parts))
+	units := make([]string, len(parts))
+	for i, part := range parts {
+		m := gajiPartPattern.FindStringSubmatch(strings.TrimSpace(part))
+		if m == nil {
+			return nil, fmt.Errorf("format gaji tidak dikenali: %q", input)
+		}
+		if _, ok := gajiMultipliers[m[2]]; !ok {
+			return nil, fmt.Errorf("satuan gaji tidak dikenali: %q", m[2])
+		}
+		n, err := parseGajiNumber(m[1])
+		if err != nil {
+			return nil, fmt.Errorf("format gaji tidak dikenali: %q", input)
+		}
+		values[i], units[i] = n, m[2]
+	}
+
+	// "5-10 juta": satuan di bagian akhir berlaku juga untuk bagian awal
+	last := units[len(units)-1]
+	for i := range values {
+		unit := units[i]
+		if unit == "" {
+			unit = last
+		}
+		values[i] *= gajiMultipliers[unit]
+	}
+
+	g := &GajiRange{
+		Min:      int64(math.Round(values[0])),
+		Max:      int64(math.Round(values[len(values)-1])),
+		MataUang: mataUang,
+	}
+	if g.Max < g.Min {
+		return nil, fmt.Errorf("gaji minimum lebih besar dari maksimum: %q", input)
+	}
+	return g, nil
+}
+
+// parseGajiNumber membaca angka dengan pemisah ribuan/desimal gaya Indonesia maupun Inggris
+func parseGajiNumber(s string) (float64, error) {
+	hasDot, hasComma := strings.Contains(s, "."), strings.Contains(s, ",")
+
+	switch {
+	case hasDot && hasComma:
+		// pemisah yang muncul terakhir adalah pemisah desimal
+		if strings.LastIndex(s, ",") > strings.LastIndex(s, ".") {
+			s = strings.ReplaceAll(s, ".", "")
+			s = strings.Replace(s, ",", ".", 1)
+		} else {
+			s = strings.ReplaceAll(s, ",", "")
+		}
+	case hasDot:
+		s = normalizeSeparator(s, ".")
+	case hasComma:
+		s = normalizeSeparator(s, ",")
+	}
+
+	return strconv.ParseFloat(s, 64)
+}
+
+// normalizeSeparator memperlakukan sep sebagai pemisah ribuan jika setiap kelompok
+// setelahnya tepat 3 digit, selain itu sebagai pemisah desimal.
+func normalizeSeparator(s, sep string) string {
+	groups := strings.Split(s, sep)
+	thousands := true
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			thousands = false
+			break
+		}
+	}
+	if thousands {
+		return strings.Join(groups, "")
+	}
+	if len(groups) == 2 {
+		return groups[0] + "." + groups[1]
+	}
+	return s
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseGajiRange(t *testing.T) {
+	cases := []struct {
+		input    string
+		min, max int64
+		mataUang string
+	}{
+		{"10000000", 10000000, 10000000, "IDR"},
+		{"Rp 7.500.000", 7500000, 7500000, "IDR"},
+		{"Rp7.500.000,-", 7500000, 7500000, "IDR"},
+		{"5-10 juta", 5000000, 10000000, "IDR"},
+		{"5jt - 10jt", 5000000, 10000000, "IDR"},
+		{"7,5 juta", 7500000, 7500000, "IDR"},
+		{"Rp 5.000.000 - Rp 10.000.000", 5000000, 10000000, "IDR"},
+		{"500 ribu s/d 1 juta", 500000, 1000000, "IDR"},
+		{"USD 1,000 - 2,500", 1000, 2500, "USD"},
+	}
+
+	for _, tc := range cases {
+		got, err := ParseGajiRange(tc.input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.input, err)
+			continue
+		}
+		if got.Min != tc.min || got.Max != tc.max || got.MataUang != tc.mataUang {
+			t.Errorf("%q: expected %d-%d %s, got %d-%d %s",
+				tc.input, tc.min, tc.max, tc.mataUang, got.Min, got.Max, got.MataUang)
+		}
+	}
+}
+
+func TestParseGajiRange_Empty(t *testing.T) {
+	got, err := ParseGajiRange("  ")
+	if err != nil || got != nil {
+		t.Errorf("expected nil, nil; got %v, %v", got, err)
+	}
+}
+
+func TestParseGajiRange_Invalid(t *testing.T) {
+	for _, input := range []string{"negotiable", "10-5 juta", "5 dolar", "1-2-3 juta"} {
+		if _, err := ParseGajiRange(input); err == nil {
+			t.Errorf("%q: expected error, got nil", input)
+		}
+	}
+}
+
+func TestPekerjaanAlumni_UnmarshalGajiRange(t *testing.T) {
+	var job PekerjaanAlumni
+	if err := json.Unmarshal([]byte(`{"gaji_range": "5-10 juta"}`), &job); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.GajiRange == nil || job.GajiRange.Min != 5000000 || job.GajiRange.Max != 10000000 {
+		t.Errorf("unexpected gaji_range: %+v", job.GajiRange)
+	}
+
+	job = PekerjaanAlumni{}
+	if err := json.Unmarshal([]byte(`{"gaji_range": {"min": 8000000}}`), &job); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.GajiRange.Max != 8000000 || job.GajiRange.MataUang != "IDR" {
+		t.Errorf("unexpected gaji_range: %+v", job.GajiRange)
+	}
+}
+
+func TestGajiRange_UnmarshalNumber(t *testing.T) {
+	var g GajiRange
+	if err := json.Unmarshal([]byte(`7500000`), &g); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Min != 7500000 || g.Max != 7500000 || g.MataUang != "IDR" {
+		t.Errorf("unexpected gaji_range: %+v", g)
+	}
+
+	for _, input := range []string{`-5000000`, `7500000.5`, `0.5`, `1e30`} {
+		if err := json.Unmarshal([]byte(input), &GajiRange{}); err == nil {
+			t.Errorf("%s: expected error, got nil", input)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package model
+
+// ImportRowResult -> hasil validasi/penyimpanan satu baris file import
+type ImportRowResult struct {
+	Baris     int      `json:"baris"`
+	NIM       string   `json:"nim"`
+	Aksi      string   `json:"aksi" example:"dibuat"` // dibuat, diperbarui, tidak_berubah, ditolak
+	Perubahan []string `json:"perubahan,omitempty"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+// ImportResult -> ringkasan import alumni
+type ImportResult struct {
+	DryRun       bool              `json:"dry_run"`
+	TotalBaris   int               `json:"total_baris"`
+	Dibuat       int               `json:"dibuat"`
+	Diperbarui   int               `json:"diperbarui"`
+	TidakBerubah int               `json:"tidak_berubah"`
+	Ditolak      int               `json:"ditolak"`
+	Baris        []ImportRowResult `json:"baris"`
+}
+
+type ImportResponse struct {
+	Message string       `json:"message"`
+	Success bool         `json:"success" example:"true"`
+	Data    ImportResult `json:"data"`
+}
@@ -13,7 +13,8 @@ type PekerjaanAlumni struct {
 	PosisiJabatan       string             `bson:"posisi_jabatan" json:"posisi_jabatan"`
 	BidangIndustri      string             `bson:"bidang_industri" json:"bidang_industri"`
 	LokasiKerja         string             `bson:"lokasi_kerja" json:"lokasi_kerja"`
-	GajiRange           string             `bson:"gaji_range" json:"gaji_range"`
+	GajiRange           *GajiRange         `bson:"gaji_range" json:"gaji_range"`
+	GajiRangeRaw        string             `bson:"gaji_range_raw,omitempty" json:"gaji_range_raw,omitempty"` // teks asli yang gagal dimigrasi
 	TanggalMulaiKerja   time.Time          `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja"`
 	TanggalSelesaiKerja *time.Time         `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja,omitempty"`
 	StatusPekerjaan     string             `bson:"status_pekerjaan" json:"status_pekerjaan"`
@@ -21,12 +22,49 @@ type PekerjaanAlumni struct {
 	CreatedAt           time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt           time.Time          `bson:"updated_at" json:"updated_at"`
 	IsDeleted           bool               `bson:"is_deleted" json:"is_deleted"`
+	DeletedAt           *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	// DeletedWithAlumni menandai pekerjaan yang masuk trash karena alumninya dihapus; hanya
+	// pekerjaan ini yang dipulihkan saat alumninya direstore
+	DeletedWithAlumni bool `bson:"deleted_with_alumni,omitempty" json:"deleted_with_alumni,omitempty"`
+}
+
+// JobScope -> batas akses pekerjaan. Dengan ScopeAll semua pekerjaan boleh diakses;
+// selain itu hanya pekerjaan milik alumni pada AlumniIDs (profil sendiri untuk ScopeOwn,
+// alumni pada jurusan user untuk ScopeJurusan).
+type JobScope struct {
+	Scope     PermissionScope
+	AlumniIDs []primitive.ObjectID
+}
+
+// Allows memeriksa apakah pekerjaan milik alumniID termasuk dalam scope
+func (s JobScope) Allows(alumniID primitive.ObjectID) bool {
+	if s.Scope == ScopeAll {
+		return true
+	}
+	for _, id := range s.AlumniIDs {
+		if id == alumniID {
+			return true
+		}
+	}
+	return false
+}
+
+// JobFilter -> filter untuk listing pekerjaan alumni
+type JobFilter struct {
+	Search          string
+	BidangIndustri  string
+	LokasiKerja     string
+	StatusPekerjaan string
+	MulaiDari       *time.Time
+	MulaiSampai     *time.Time
+	// Scope membatasi hasil ke pekerjaan dalam cakupan user; nil berarti tanpa batas
+	Scope *JobScope
 }
 
 type TotalJobAlumni struct {
 	AlumniID            primitive.ObjectID `bson:"alumni_id" json:"alumni_id"`
 	NamaAlumni			string             	`bson:"nama_alumni" json:"nama_alumni"`
-	Count				int    				`bson:"count"json:"count"`
+	Count				int    				`bson:"count" json:"count"`
 }
 
 type Trash struct {
@@ -35,4 +73,11 @@ type Trash struct {
 	NamaAlumni			string             	`bson:"nama_alumni" json:"nama_alumni"`
  	NamaPerusahaan      string             `bson:"nama_perusahaan" json:"nama_perusahaan"`
 	IsDeleted           bool               `bson:"is_deleted" json:"is_deleted"`
+	DeletedAt           *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+}
+
+// PekerjaanWithAlumni -> pekerjaan beserta data alumninya (hasil $lookup)
+type PekerjaanWithAlumni struct {
+	PekerjaanAlumni `bson:",inline"`
+	Alumni          *Alumni `bson:"alumni,omitempty" json:"alumni,omitempty"`
 }
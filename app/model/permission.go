@@ -0,0 +1,151 @@
+package model
+
+import "strings"
+
+// Permission -> hak akses dalam format "resource:aksi". Permission yang diberi akhiran
+// ":own" hanya berlaku untuk data milik user sendiri, misalnya "job:update:own".
+type Permission string
+
+const (
+	PermAlumniRead     Permission = "alumni:read"
+	PermAlumniCreate   Permission = "alumni:create"
+	PermAlumniUpdate   Permission = "alumni:update"
+	PermAlumniDelete   Permission = "alumni:delete"
+	PermAlumniExport   Permission = "alumni:export"
+	PermAlumniImport   Permission = "alumni:import"
+	PermAlumniClaim    Permission = "alumni:claim"
+	PermAlumniTimeline Permission = "alumni:timeline"
+	PermAlumniHistory  Permission = "alumni:history"
+	PermAlumniRollback Permission = "alumni:rollback"
+	PermAlumniTrash    Permission = "alumni:trash"
+	PermAlumniRestore  Permission = "alumni:restore"
+	PermAlumniPurge    Permission = "alumni:purge"
+
+	PermJobRead       Permission = "job:read"
+	PermJobReadAlumni Permission = "job:read_alumni"
+	PermJobCreate     Permission = "job:create"
+	PermJobUpdate     Permission = "job:update"
+	PermJobDelete     Permission = "job:delete"
+	PermJobTrash      Permission = "job:trash"
+	PermJobRestore    Permission = "job:restore"
+	PermJobHardDelete Permission = "job:hard_delete"
+	PermJobExport     Permission = "job:export"
+	PermJobPurge      Permission = "job:purge"
+
+	PermFileRead   Permission = "file:read"
+	PermFileUpload Permission = "file:upload"
+	PermFileDelete Permission = "file:delete"
+
+	PermStatistikRead Permission = "statistik:read"
+
+	PermUserCreate Permission = "user:create"
+	PermUserRevoke Permission = "user:revoke_sessions"
+
+	PermAuditRead Permission = "audit:read"
+)
+
+// PermissionWildcard memberi semua permission, dipakai untuk role admin
+const PermissionWildcard = "*"
+
+// PermissionScope -> cakupan data yang boleh diakses setelah lolos pengecekan permission.
+// Permission tanpa akhiran berlaku untuk semua data; akhiran ":own" membatasi ke data milik
+// user sendiri dan ":jurusan" ke data alumni pada jurusan yang tercatat di akun user.
+type PermissionScope string
+
+const (
+	ScopeAll     PermissionScope = "all"
+	ScopeJurusan PermissionScope = "jurusan"
+	ScopeOwn     PermissionScope = "own"
+)
+
+// KnownPermissions adalah daftar permission yang dikenali beserta cakupan terbatas yang
+// didukung handler-nya. Permission yang hanya boleh diberikan penuh bernilai nil.
+var KnownPermissions = map[Permission][]PermissionScope{
+	PermAlumniRead:     {ScopeJurusan},
+	PermAlumniCreate:   {ScopeOwn, ScopeJurusan},
+	PermAlumniUpdate:   {ScopeJurusan},
+	PermAlumniDelete:   nil,
+	PermAlumniExport:   nil,
+	PermAlumniImport:   nil,
+	PermAlumniClaim:    nil,
+	PermAlumniTimeline: {ScopeJurusan},
+	PermAlumniHistory:  {ScopeJurusan},
+	PermAlumniRollback: {ScopeJurusan},
+	PermAlumniTrash:    nil,
+	PermAlumniRestore:  nil,
+	PermAlumniPurge:    nil,
+
+	PermJobRead:       {ScopeJurusan},
+	PermJobReadAlumni: {ScopeOwn, ScopeJurusan},
+	PermJobCreate:     {ScopeOwn, ScopeJurusan},
+	PermJobUpdate:     {ScopeOwn, ScopeJurusan},
+	PermJobDelete:     {ScopeOwn, ScopeJurusan},
+	PermJobTrash:      {ScopeOwn, ScopeJurusan},
+	PermJobRestore:    {ScopeOwn, ScopeJurusan},
+	PermJobHardDelete: {ScopeOwn, ScopeJurusan},
+	PermJobExport:     nil,
+	PermJobPurge:      nil,
+
+	PermFileRead:   {ScopeOwn, ScopeJurusan},
+	PermFileUpload: {ScopeOwn},
+	PermFileDelete: {ScopeOwn},
+
+	PermStatistikRead: nil,
+
+	PermUserCreate: nil,
+	PermUserRevoke: nil,
+
+	PermAuditRead: nil,
+}
+
+// Own mengembalikan versi owner-scoped dari permission, misalnya "file:read:own"
+func (p Permission) Own() Permission {
+	return p + ":" + Permission(ScopeOwn)
+}
+
+// InJurusan mengembalikan versi jurusan-scoped dari permission, misalnya "alumni:read:jurusan"
+func (p Permission) InJurusan() Permission {
+	return p + ":" + Permission(ScopeJurusan)
+}
+
+// SplitScope memisahkan akhiran cakupan dari permission. Permission tanpa akhiran
+// dikembalikan dengan ScopeAll.
+func (p Permission) SplitScope() (Permission, PermissionScope) {
+	for _, scope := range []PermissionScope{ScopeOwn, ScopeJurusan} {
+		if base, ok := strings.CutSuffix(string(p), ":"+string(scope)); ok {
+			return Permission(base), scope
+		}
+	}
+	return p, ScopeAll
+}
+
+// JurusanScope -> pembatasan data berdasarkan jurusan. Jika Restricted, hanya data pada
+// daftar Jurusan yang boleh diakses (daftar kosong berarti tidak ada data sama sekali).
+type JurusanScope struct {
+	Restricted bool
+	Jurusan    []string
+}
+
+// Allows memeriksa apakah jurusan termasuk dalam cakupan, tanpa membedakan huruf besar/kecil
+func (s JurusanScope) Allows(jurusan string) bool {
+	if !s.Restricted {
+		return true
+	}
+	for _, j := range s.Jurusan {
+		if strings.EqualFold(strings.TrimSpace(j), strings.TrimSpace(jurusan)) {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleDefinition -> dokumen pada collection roles atau entri pada file policy
+type RoleDefinition struct {
+	Name        string   `bson:"name" json:"name"`
+	Permissions []string `bson:"permissions" json:"permissions"`
+}
+
+// RBACPolicyFile -> format file policy yang ditunjuk RBAC_POLICY_FILE
+type RBACPolicyFile struct {
+	Roles []RoleDefinition `json:"roles"`
+}
@@ -0,0 +1,39 @@
+package model
+
+import "testing"
+
+func TestPermissionSplitScope(t *testing.T) {
+	tests := []struct {
+		perm  Permission
+		base  Permission
+		scope PermissionScope
+	}{
+		{"job:update", PermJobUpdate, ScopeAll},
+		{"job:update:own", PermJobUpdate, ScopeOwn},
+		{"alumni:read:jurusan", PermAlumniRead, ScopeJurusan},
+	}
+
+	for _, tt := range tests {
+		base, scope := tt.perm.SplitScope()
+		if base != tt.base || scope != tt.scope {
+			t.Errorf("SplitScope(%q) = %q %q, want %q %q", tt.perm, base, scope, tt.base, tt.scope)
+		}
+	}
+}
+
+func TestJurusanScopeAllows(t *testing.T) {
+	if !(JurusanScope{}).Allows("Teknik Informatika") {
+		t.Error("unrestricted scope should allow every jurusan")
+	}
+
+	scope := JurusanScope{Restricted: true, Jurusan: []string{"Teknik Informatika"}}
+	if !scope.Allows(" teknik informatika") {
+		t.Error("jurusan should match case-insensitively")
+	}
+	if scope.Allows("Sistem Informasi") {
+		t.Error("other jurusan must be rejected")
+	}
+	if (JurusanScope{Restricted: true}).Allows("Teknik Informatika") {
+		t.Error("restricted scope without jurusan must reject everything")
+	}
+}
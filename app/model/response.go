@@ -37,6 +37,13 @@ type PekerjaanAlumniResponse struct {
     Meta MetaInfo          `json:"meta"`
 }
 
+type AlumniTrashResponse struct {
+	Message string        `json:"message"`
+	Success bool          `json:"success"`
+	Data    []AlumniTrash `json:"data"`
+	Meta    MetaInfo      `json:"meta"`
+}
+
 type TrashResponse struct {
     Message string    `json:"message"`
     Success bool      `json:"success"`
@@ -48,10 +55,14 @@ type ResponseStandard struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 }
+// ErrorResponse -> format tunggal untuk semua response error. Code adalah status HTTP,
+// ErrorCode kode error yang dibaca mesin, dan Details berisi detail validasi per field.
 type ErrorResponse struct {
-	Success bool   `json:"success" example:"false"`
-	Message string `json:"message" example:"Token tidak valid"`
-	Code    int    `json:"code" example:"401"`
+	Success   bool         `json:"success" example:"false"`
+	Message   string       `json:"message" example:"Token tidak valid"`
+	Code      int          `json:"code" example:"401"`
+	ErrorCode ErrorKind    `json:"error_code" example:"UNAUTHORIZED"`
+	Details   []FieldError `json:"details,omitempty"`
 }
 
 type SuccessResponse struct {
@@ -0,0 +1,52 @@
+package model
+
+// StatistikFilter -> filter opsional untuk statistik tracer study
+type StatistikFilter struct {
+	Jurusan    string
+	TahunLulus int
+}
+
+// EmploymentRate -> tingkat keterserapan kerja per kelompok (angkatan/jurusan)
+type EmploymentRate struct {
+	Kelompok    string  `bson:"kelompok" json:"kelompok"`
+	TotalAlumni int     `bson:"total_alumni" json:"total_alumni"`
+	Bekerja     int     `bson:"bekerja" json:"bekerja"`
+	Persentase  float64 `bson:"persentase" json:"persentase"`
+}
+
+// MasaTunggu -> ringkasan lama waktu dari tahun lulus sampai pekerjaan pertama (dalam bulan)
+type MasaTunggu struct {
+	JumlahAlumni  int     `json:"jumlah_alumni"`
+	MedianBulan   float64 `json:"median_bulan"`
+	RataRataBulan float64 `json:"rata_rata_bulan"`
+}
+
+// DistribusiItem -> jumlah pekerjaan untuk satu nilai (bidang industri/lokasi kerja)
+type DistribusiItem struct {
+	Nilai  string `bson:"nilai" json:"nilai"`
+	Jumlah int    `bson:"jumlah" json:"jumlah"`
+}
+
+// SalaryBand -> jumlah pekerjaan dalam satu rentang gaji minimum
+type SalaryBand struct {
+	Label  string `json:"label"`
+	Dari   int64  `json:"dari"`
+	Sampai int64  `json:"sampai"`
+	Jumlah int    `json:"jumlah"`
+}
+
+// StatistikDashboard -> seluruh metrik tracer study dalam satu respons
+type StatistikDashboard struct {
+	PerAngkatan    []EmploymentRate `json:"per_angkatan"`
+	PerJurusan     []EmploymentRate `json:"per_jurusan"`
+	MasaTunggu     MasaTunggu       `json:"masa_tunggu"`
+	BidangIndustri []DistribusiItem `json:"bidang_industri"`
+	LokasiKerja    []DistribusiItem `json:"lokasi_kerja"`
+	RentangGaji    []SalaryBand     `json:"rentang_gaji"`
+}
+
+type StatistikDashboardResponse struct {
+	Message string             `json:"message"`
+	Success bool               `json:"success" example:"true"`
+	Data    StatistikDashboard `json:"data"`
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TimelineEntry -> satu pekerjaan di riwayat karier beserta hasil analisisnya
+type TimelineEntry struct {
+	PekerjaanAlumni
+	MasaKerjaHari  int                  `json:"masa_kerja_hari"`
+	MasaKerjaBulan int                  `json:"masa_kerja_bulan"`
+	IsCurrent      bool                 `json:"is_current"`
+	OverlapDengan  []primitive.ObjectID `json:"overlap_dengan"`
+}
+
+// TimelineGap -> jeda tanpa pekerjaan di antara dua pekerjaan
+type TimelineGap struct {
+	Dari             time.Time          `json:"dari"`
+	Sampai           time.Time          `json:"sampai"`
+	Hari             int                `json:"hari"`
+	SetelahPekerjaan primitive.ObjectID `json:"setelah_pekerjaan"`
+	SebelumPekerjaan primitive.ObjectID `json:"sebelum_pekerjaan"`
+}
+
+// CareerTimeline -> riwayat karier satu alumni yang diurutkan berdasarkan tanggal mulai kerja
+type CareerTimeline struct {
+	Alumni              Alumni          `json:"alumni"`
+	Pekerjaan           []TimelineEntry `json:"pekerjaan"`
+	Gaps                []TimelineGap   `json:"gaps"`
+	PekerjaanSaatIni    int             `json:"pekerjaan_saat_ini"`
+	TotalMasaKerjaBulan int             `json:"total_masa_kerja_bulan"`
+}
+
+type CareerTimelineResponse struct {
+	Message string         `json:"message"`
+	Success bool           `json:"success" example:"true"`
+	Data    CareerTimeline `json:"data"`
+}
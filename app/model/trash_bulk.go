@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status hasil per ID pada operasi bulk
+const (
+	BulkStatusOK        = "ok"
+	BulkStatusInvalidID = "invalid_id"
+	BulkStatusNotFound  = "not_found"
+	BulkStatusConflict  = "conflict"
+	BulkStatusError     = "error"
+)
+
+// BulkTrashRequest -> pemilihan pekerjaan untuk operasi trash bulk. Isi ids, atau filter
+// alumni_id dan/atau deleted_before (YYYY-MM-DD, hanya untuk restore dan hapus permanen).
+type BulkTrashRequest struct {
+	IDs           []string `json:"ids" example:"665f1c2e8b3a4d0012345678"`
+	AlumniID      string   `json:"alumni_id,omitempty"`
+	DeletedBefore string   `json:"deleted_before,omitempty" example:"2024-01-31"`
+}
+
+// BulkJobFilter -> filter pekerjaan hasil parsing BulkTrashRequest
+type BulkJobFilter struct {
+	// Trashed memilih pekerjaan di trash; false memilih pekerjaan aktif
+	Trashed       bool
+	AlumniID      *primitive.ObjectID
+	DeletedBefore *time.Time
+}
+
+// BulkItemResult -> hasil operasi bulk untuk satu ID
+type BulkItemResult struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// BulkTrashResult -> ringkasan operasi bulk. Sisa adalah jumlah pekerjaan yang cocok dengan
+// filter tetapi belum diproses karena batas jumlah per request.
+type BulkTrashResult struct {
+	Diproses int              `json:"diproses"`
+	Berhasil int              `json:"berhasil"`
+	Gagal    int              `json:"gagal"`
+	Sisa     int              `json:"sisa"`
+	Results  []BulkItemResult `json:"results"`
+}
+
+type BulkTrashResponse struct {
+	Message string          `json:"message"`
+	Success bool            `json:"success"`
+	Data    BulkTrashResult `json:"data"`
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TrashPurgeCandidate -> pekerjaan di trash yang akan dihapus permanen oleh purge otomatis
+type TrashPurgeCandidate struct {
+	ID             primitive.ObjectID `json:"id"`
+	AlumniID       primitive.ObjectID `json:"alumni_id"`
+	NamaPerusahaan string             `json:"nama_perusahaan"`
+	DeletedAt      *time.Time         `json:"deleted_at,omitempty"`
+}
+
+// TrashPurgeReport -> hasil dry-run purge: pekerjaan yang dihapus sebelum Cutoff akan dipurge
+type TrashPurgeReport struct {
+	RetentionDays int                   `json:"retention_days"`
+	Cutoff        time.Time             `json:"cutoff"`
+	Total         int                   `json:"total"`
+	Jobs          []TrashPurgeCandidate `json:"jobs"`
+}
+
+// TrashPurgeMetrics -> status worker purge otomatis pada instance server ini
+type TrashPurgeMetrics struct {
+	Enabled        bool       `json:"enabled"`
+	Instance       string     `json:"instance"`
+	RetentionDays  int        `json:"retention_days"`
+	Interval       string     `json:"interval"`
+	Runs           int64      `json:"runs"`
+	SkippedRuns    int64      `json:"skipped_runs"`
+	FailedRuns     int64      `json:"failed_runs"`
+	TotalPurged    int64      `json:"total_purged"`
+	LastRunAt      *time.Time `json:"last_run_at,omitempty"`
+	LastDurationMs int64      `json:"last_duration_ms"`
+	LastPurged     int        `json:"last_purged"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextRunAt      *time.Time `json:"next_run_at,omitempty"`
+}
+
+type TrashPurgeReportResponse struct {
+	Message string           `json:"message"`
+	Success bool             `json:"success"`
+	Data    TrashPurgeReport `json:"data"`
+}
+
+type TrashPurgeMetricsResponse struct {
+	Message string            `json:"message"`
+	Success bool              `json:"success"`
+	Data    TrashPurgeMetrics `json:"data"`
+}
@@ -3,7 +3,8 @@ package repository
 import (
 	"context"
 	"fmt"
-	_ "log"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/noorfarihaf11/clean-arc/app/model"
@@ -11,40 +12,184 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
-	_ "go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func GetAllAlumni(db *mongo.Database) ([]model.Alumni, error) {
+// Nama index unik alumni, dipakai untuk membedakan pelanggaran index mana yang terjadi
+const (
+	alumniNIMIndex  = "nim_active_unique"
+	alumniUserIndex = "user_id_unique"
+)
+
+// EnsureAlumniIndexes membuat index unik NIM untuk alumni aktif (NIM kosong dari akun yang dibuat
+// admin dikecualikan) dan satu profil alumni per akun. Dokumen lama tanpa is_deleted dilengkapi
+// lebih dulu agar ikut tercakup index parsial. Gagal jika data yang ada sudah ganda; data ganda
+// harus dibereskan dulu lewat pemeriksaan klaim.
+func EnsureAlumniIndexes(db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	coll := db.Collection("alumni")
+	if _, err := coll.UpdateMany(ctx,
+		bson.M{"is_deleted": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"is_deleted": false}},
+	); err != nil {
+		return fmt.Errorf("gagal melengkapi is_deleted alumni: %v", err)
+	}
+
+	_, err := coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "nim", Value: 1}},
+			Options: options.Index().SetName(alumniNIMIndex).SetUnique(true).
+				SetPartialFilterExpression(bson.M{"nim": bson.M{"$gt": ""}, "is_deleted": false}),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetName(alumniUserIndex).SetUnique(true).
+				SetPartialFilterExpression(bson.M{"user_id": bson.M{"$type": "objectId"}}),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("gagal membuat index alumni: %v", err)
+	}
+	return nil
+}
+
+// alumniWriteError menerjemahkan pelanggaran index unik alumni menjadi error conflict
+func alumniWriteError(err error) error {
+	if !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	if strings.Contains(err.Error(), alumniUserIndex) {
+		return ErrAlumniProfileExists
+	}
+	return ErrAlumniNIMTaken
+}
+
+// alumniSearchFilter membangun filter pencarian case-insensitive untuk nama, nim, dan jurusan
+func alumniSearchFilter(search string) bson.M {
+	if search == "" {
+		return bson.M{}
+	}
+
+	regex := primitive.Regex{Pattern: regexp.QuoteMeta(search), Options: "i"}
+	return bson.M{
+		"$or": []bson.M{
+			{"nama": regex},
+			{"nim": regex},
+			{"jurusan": regex},
+		},
+	}
+}
+
+// activeAlumni membatasi filter ke alumni yang tidak ada di trash. Dokumen lama yang dibuat
+// sebelum ada soft delete tidak memiliki field is_deleted, sehingga dipakai $ne true.
+func activeAlumni(filter bson.M) bson.M {
+	filter["is_deleted"] = bson.M{"$ne": true}
+	return filter
+}
+
+// withJurusanScope membatasi filter ke jurusan dalam scope. field adalah path jurusan pada
+// dokumen, misalnya "jurusan" atau "alumni_info.jurusan" setelah $lookup.
+func withJurusanScope(filter bson.M, field string, scope model.JurusanScope) bson.M {
+	if !scope.Restricted {
+		return filter
+	}
+
+	in := bson.A{}
+	for _, j := range scope.Jurusan {
+		in = append(in, exactInsensitive(strings.TrimSpace(j)))
+	}
+	filter[field] = bson.M{"$in": in}
+	return filter
+}
+
+// GetAlumniIDsByJurusan mengambil ID semua alumni pada jurusan dalam scope
+func GetAlumniIDsByJurusan(db *mongo.Database, scope model.JurusanScope) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := withJurusanScope(activeAlumni(bson.M{}), "jurusan", scope)
+	cursor, err := db.Collection("alumni").Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ids := []primitive.ObjectID{}
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		ids = append(ids, doc.ID)
+	}
+	return ids, cursor.Err()
+}
+
+// GetAlumniRepo mengambil data alumni dalam scope dengan pencarian, sorting, dan pagination
+func GetAlumniRepo(db *mongo.Database, search string, scope model.JurusanScope, sortBy, order string, limit, offset int) ([]model.Alumni, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	collection := db.Collection("alumni")
-	cursor, err := collection.Find(ctx, bson.M{}) // tanpa filter karena tidak ada is_deleted
+	sortOrder := 1
+	if order == "desc" {
+		sortOrder = -1
+	}
+
+	// _id sebagai tie-breaker agar urutan antar halaman stabil
+	sort := bson.D{{Key: sortBy, Value: sortOrder}}
+	if sortBy != "_id" {
+		sort = append(sort, bson.E{Key: "_id", Value: sortOrder})
+	}
+
+	opts := options.Find().
+		SetSort(sort).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	filter := withJurusanScope(activeAlumni(alumniSearchFilter(search)), "jurusan", scope)
+	cursor, err := db.Collection("alumni").Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var alumniList []model.Alumni
+	alumniList := []model.Alumni{}
 	if err = cursor.All(ctx, &alumniList); err != nil {
 		return nil, err
 	}
 	return alumniList, nil
 }
 
+// CountAlumniRepo menghitung total alumni dalam scope yang cocok dengan pencarian
+func CountAlumniRepo(db *mongo.Database, search string, scope model.JurusanScope) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := withJurusanScope(activeAlumni(alumniSearchFilter(search)), "jurusan", scope)
+	total, err := db.Collection("alumni").CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return int(total), nil
+}
+
+// GetAlumniByID mengambil alumni aktif, ErrAlumniNotFound jika tidak ada
 func GetAlumniByID(db *mongo.Database, id string) (*model.Alumni, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, fmt.Errorf("ID tidak valid: %v", err)
+		return nil, model.NewValidationError("ID tidak valid")
 	}
 
 	var job model.Alumni
-	err = db.Collection("alumni").FindOne(ctx, bson.M{"_id": objID}).Decode(&job)
+	err = db.Collection("alumni").FindOne(ctx, activeAlumni(bson.M{"_id": objID})).Decode(&job)
 	if err == mongo.ErrNoDocuments {
-		return nil, nil
+		return nil, ErrAlumniNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -52,6 +197,46 @@ func GetAlumniByID(db *mongo.Database, id string) (*model.Alumni, error) {
 	return &job, nil
 }
 
+// GetAlumniByUserID mengambil profil alumni milik user, ErrAlumniNotFound jika user belum punya profil
+func GetAlumniByUserID(db *mongo.Database, userID primitive.ObjectID) (*model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var alumni model.Alumni
+	err := db.Collection("alumni").FindOne(ctx, activeAlumni(bson.M{"user_id": userID})).Decode(&alumni)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrAlumniNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &alumni, nil
+}
+
+// UpdateAlumniFields memperbarui sebagian field alumni dan mengembalikan dokumen terbaru.
+// Field bernilai nil dihapus dari dokumen (diset null). ErrAlumniNotFound jika alumni tidak aktif.
+func UpdateAlumniFields(db *mongo.Database, id primitive.ObjectID, fields bson.M) (*model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	set := bson.M{"updated_at": time.Now()}
+	for k, v := range fields {
+		set[k] = v
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated model.Alumni
+	err := db.Collection("alumni").FindOneAndUpdate(ctx, activeAlumni(bson.M{"_id": id}), bson.M{"$set": set}, opts).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrAlumniNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
 func CreateAlumni(db *mongo.Database, alumni *model.Alumni, userID *primitive.ObjectID) (*model.Alumni, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -59,6 +244,9 @@ func CreateAlumni(db *mongo.Database, alumni *model.Alumni, userID *primitive.Ob
 	alumni.ID = primitive.NewObjectID()
 	alumni.CreatedAt = time.Now()
 	alumni.UpdatedAt = time.Now()
+	alumni.IsDeleted = false
+	alumni.DeletedAt = nil
+	alumni.DeletedBy = nil
 
 	if userID != nil {
 		alumni.UserID = userID
@@ -66,20 +254,21 @@ func CreateAlumni(db *mongo.Database, alumni *model.Alumni, userID *primitive.Ob
 
 	_, err := db.Collection("alumni").InsertOne(ctx, alumni)
 	if err != nil {
-		return nil, err
+		return nil, alumniWriteError(err)
 	}
 	return alumni, nil
 }
 
 
-func UpdateAlumni(db *mongo.Database, id string, data *model.Alumni) (*model.Alumni, error) {
+// UpdateAlumni memperbarui alumni dalam scope; alumni di luar scope dianggap tidak ditemukan
+func UpdateAlumni(db *mongo.Database, id string, data *model.Alumni, scope model.JurusanScope) (*model.Alumni, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	// Konversi ID string ke ObjectID
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, fmt.Errorf("ID tidak valid: %v", err)
+		return nil, model.NewValidationError("ID tidak valid")
 	}
 
 	data.UpdatedAt = time.Now()
@@ -103,11 +292,14 @@ func UpdateAlumni(db *mongo.Database, id string, data *model.Alumni) (*model.Alu
 
 	var updatedAlumni model.Alumni
 	err = db.Collection("alumni").
-		FindOneAndUpdate(ctx, bson.M{"_id": objID}, update, opts).
+		FindOneAndUpdate(ctx, withJurusanScope(activeAlumni(bson.M{"_id": objID}), "jurusan", scope), update, opts).
 		Decode(&updatedAlumni)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, fmt.Errorf("data alumni dengan ID %s tidak ditemukan", id)
+			return nil, ErrAlumniNotFound
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, alumniWriteError(err)
 		}
 		return nil, fmt.Errorf("gagal memperbarui data: %v", err)
 	}
@@ -116,151 +308,477 @@ func UpdateAlumni(db *mongo.Database, id string, data *model.Alumni) (*model.Alu
 }
 
 
-func DeleteAlumni(db *mongo.Database, id string) error {
+// SoftDeleteAlumni memindahkan alumni aktif ke trash beserta pekerjaannya yang masih aktif.
+// Pekerjaan yang sudah ada di trash sebelumnya tidak ditandai agar tidak ikut dipulihkan.
+// Mengembalikan ErrAlumniNotFound jika alumni tidak ditemukan atau sudah ada di trash.
+func SoftDeleteAlumni(db *mongo.Database, id string, deletedBy *primitive.ObjectID) (*model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, model.NewValidationError("ID tidak valid")
+	}
+
+	now := time.Now()
+	var trashed *model.Alumni
+	err = runInTransaction(ctx, db, func(ctx context.Context) error {
+		count, err := db.Collection("alumni").CountDocuments(ctx, activeAlumni(bson.M{"_id": objID}))
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrAlumniNotFound
+		}
+
+		// pekerjaan ditandai lebih dulu agar tanpa transaksi, kegagalan di tengah jalan bisa
+		// diulang: alumni tetap aktif sampai langkah terakhir berhasil
+		_, err = db.Collection("pekerjaan_alumni").UpdateMany(ctx,
+			bson.M{"alumni_id": objID, "is_deleted": false},
+			bson.M{"$set": bson.M{"is_deleted": true, "deleted_with_alumni": true, "deleted_at": now, "updated_at": now}},
+		)
+		if err != nil {
+			return fmt.Errorf("gagal menghapus pekerjaan alumni: %v", err)
+		}
+
+		update := bson.M{"$set": bson.M{
+			"is_deleted": true,
+			"deleted_at": now,
+			"deleted_by": deletedBy,
+			"updated_at": now,
+		}}
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+		var alumni model.Alumni
+		err = db.Collection("alumni").FindOneAndUpdate(ctx, activeAlumni(bson.M{"_id": objID}), update, opts).Decode(&alumni)
+		if err != nil {
+			return fmt.Errorf("gagal menghapus data: %v", err)
+		}
+		trashed = &alumni
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return trashed, nil
+}
+
+// GetDeletedAlumniByID mengambil alumni yang ada di trash, ErrAlumniNotInTrash jika tidak ada
+func GetDeletedAlumniByID(db *mongo.Database, id string) (*model.Alumni, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Ubah string ID menjadi ObjectID
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return fmt.Errorf("ID tidak valid: %v", err)
+		return nil, model.NewValidationError("ID tidak valid")
 	}
 
-	// Hapus dokumen berdasarkan _id
-	result, err := db.Collection("alumni").DeleteOne(ctx, bson.M{"_id": objID})
+	var alumni model.Alumni
+	err = db.Collection("alumni").FindOne(ctx, bson.M{"_id": objID, "is_deleted": true}).Decode(&alumni)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrAlumniNotInTrash
+	}
 	if err != nil {
-		return fmt.Errorf("gagal menghapus data: %v", err)
+		return nil, err
+	}
+	return &alumni, nil
+}
+
+// GetAlumniTrash mengambil alumni di trash beserta jumlah pekerjaan yang ikut terhapus
+// bersamanya dalam satu aggregation, dengan pencarian nama, nim, atau jurusan, sorting, dan
+// pagination. Mengembalikan data halaman saat ini dan total alumni yang cocok.
+func GetAlumniTrash(db *mongo.Database, search, sortBy, order string, limit, offset int) ([]model.AlumniTrash, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	sortOrder := 1
+	if order == "desc" {
+		sortOrder = -1
+	}
+	sort := bson.D{{Key: sortBy, Value: sortOrder}}
+	if sortBy != "_id" {
+		sort = append(sort, bson.E{Key: "_id", Value: sortOrder})
 	}
 
-	// Jika tidak ada dokumen yang terhapus
-	if result.DeletedCount == 0 {
-		return fmt.Errorf("alumni dengan ID %s tidak ditemukan", id)
+	match := alumniSearchFilter(search)
+	match["is_deleted"] = true
+
+	pipeline := []bson.M{
+		{"$match": match},
+		{"$facet": bson.M{
+			"data": []bson.M{
+				{"$sort": sort},
+				{"$skip": offset},
+				{"$limit": limit},
+				// jumlah pekerjaan dihitung setelah pagination, hanya untuk alumni di halaman ini
+				{"$lookup": bson.M{
+					"from": "pekerjaan_alumni",
+					"let":  bson.M{"alumni_id": "$_id"},
+					"pipeline": []bson.M{
+						{"$match": bson.M{
+							"deleted_with_alumni": true,
+							"$expr":               bson.M{"$eq": bson.A{"$alumni_id", "$$alumni_id"}},
+						}},
+						{"$count": "count"},
+					},
+					"as": "pekerjaan",
+				}},
+				{"$project": bson.M{
+					"nim":              1,
+					"nama":             1,
+					"jurusan":          1,
+					"deleted_at":       1,
+					"deleted_by":       1,
+					"jumlah_pekerjaan": bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$pekerjaan.count", 0}}, 0}},
+				}},
+			},
+			"total": []bson.M{{"$count": "count"}},
+		}},
 	}
 
-	return nil
+	cursor, err := db.Collection("alumni").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Data  []model.AlumniTrash `bson:"data"`
+		Total []struct {
+			Count int `bson:"count"`
+		} `bson:"total"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
+
+	trashList := []model.AlumniTrash{}
+	total := 0
+	if len(result) > 0 {
+		if result[0].Data != nil {
+			trashList = result[0].Data
+		}
+		if len(result[0].Total) > 0 {
+			total = result[0].Total[0].Count
+		}
+	}
+	return trashList, total, nil
 }
 
+// RestoreAlumni mengembalikan alumni dari trash bersama pekerjaan yang ikut terhapus
+// bersamanya. Mengembalikan ErrAlumniNotInTrash jika alumni tidak ada di trash.
+func RestoreAlumni(db *mongo.Database, id string) (*model.Alumni, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-// func GetAlumniWithHighSalary(db *sql.DB) ([]model.AlumniWithSalary, error) {
-// 	query := `
-//         SELECT a.id, a.nim, a.nama, a.jurusan, a.angkatan,
-//                p.nama_perusahaan, p.posisi_jabatan, p.gaji_range
-//         FROM alumni a
-//         JOIN pekerjaan_alumni p ON p.alumni_id = a.id
-//         WHERE CAST(p.gaji_range AS INT) > 19000000
-//     `
-// 	rows, err := db.Query(query)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	defer rows.Close()
-
-// 	var results []model.AlumniWithSalary
-// 	for rows.Next() {
-// 		var a model.AlumniWithSalary
-// 		err := rows.Scan(
-// 			&a.AlumniID, &a.NIM, &a.NamaAlumni, &a.Jurusan, &a.Angkatan,
-// 			&a.NamaPerusahaan, &a.PosisiJabatan, &a.GajiRange,
-// 		)
-// 		if err != nil {
-// 			return nil, err
-// 		}
-// 		results = append(results, a)
-// 	}
-
-// 	return results, nil
-// }
-
-// func GetAllAlumniByYear(db *sql.DB) ([]model.Alumni, error) {
-// 	rows, err := db.Query(`SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at 
-//     FROM alumni
-//     WHERE tahun_lulus = 2025`)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	defer rows.Close()
-
-// 	var alumniList []model.Alumni
-// 	for rows.Next() {
-// 		var a model.Alumni
-// 		err := rows.Scan(&a.ID, &a.NIM, &a.Nama, &a.Jurusan, &a.Angkatan, &a.TahunLulus, &a.Email, &a.NoTelp, &a.Alamat, &a.CreatedAt, &a.UpdatedAt)
-// 		if err != nil {
-// 			return nil, err
-// 		}
-// 		alumniList = append(alumniList, a)
-// 	}
-
-// 	return alumniList, nil
-// }
-
-// func GetAlumniWithYear(db *sql.DB) ([]model.AlumniWithYear, error) {
-// 	query := `
-//         SELECT a.id, a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus,
-//                p.nama_perusahaan, p.posisi_jabatan, p.gaji_range, p.tanggal_mulai_kerja
-//         FROM alumni a
-//          JOIN pekerjaan_alumni p ON a.id = p.alumni_id
-//         WHERE a.tahun_lulus = EXTRACT(YEAR FROM p.tanggal_mulai_kerja)
-
-//     `
-
-// 	rows, err := db.Query(query)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	defer rows.Close()
-
-// 	var results []model.AlumniWithYear
-// 	for rows.Next() {
-// 		var a model.AlumniWithYear
-// 		err := rows.Scan(
-// 			&a.AlumniID, &a.NIM, &a.NamaAlumni, &a.Jurusan, &a.Angkatan, &a.TahunLulus,
-// 			&a.NamaPerusahaan, &a.PosisiJabatan, &a.GajiRange, &a.TanggalMulaiKerja,
-// 		)
-// 		if err != nil {
-// 			return nil, err
-// 		}
-// 		results = append(results, a)
-// 	}
-
-// 	return results, nil
-// }
-// func GetAlumniRepo(db *sql.DB, search, sortBy, order string, limit, offset int) ([]model.Alumni, error) {
-//     query := fmt.Sprintf(`
-//         SELECT id, nama, nim, jurusan, angkatan, tahun_lulus, no_telepon, alamat 
-//         FROM alumni
-//         WHERE nama ILIKE $1 OR nim ILIKE $1 OR jurusan ILIKE $1
-//         ORDER BY %s %s
-//         LIMIT $2 OFFSET $3
-//     `, sortBy, order)
-
-//     rows, err := db.Query(query, "%"+search+"%", limit, offset)
-//     if err != nil {
-//         log.Println("Query error:", err)
-//         return nil, err
-//     }
-//     defer rows.Close()
-
-//     var alumni []model.Alumni
-//     for rows.Next() {
-//         var a model.Alumni
-//         if err := rows.Scan(&a.ID, &a.Nama, &a.NIM, &a.Jurusan, &a.Angkatan, &a.TahunLulus, &a.NoTelp, &a.Alamat); err != nil {
-//             return nil, err
-//         }
-//         alumni = append(alumni, a)
-//     }
-
-//     return alumni, nil
-// }
-
-// func CountAlumniRepo(db *sql.DB, search string) (int, error) {
-//     var total int
-//     countQuery := `SELECT COUNT(*) FROM alumni WHERE nama ILIKE $1 OR nim ILIKE $1 OR jurusan ILIKE $1`
-//     err := db.QueryRow(countQuery, "%"+search+"%").Scan(&total)
-//     if err != nil && err != sql.ErrNoRows {
-//         return 0, err
-//     }
-//     return total, nil
-// }
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, 0, model.NewValidationError("ID tidak valid")
+	}
 
+	now := time.Now()
+	var restored *model.Alumni
+	var jobs int64
+	err = runInTransaction(ctx, db, func(ctx context.Context) error {
+		// pekerjaan dipulihkan lebih dulu agar tanpa transaksi, kegagalan di tengah jalan
+		// bisa diulang: alumni tetap di trash sampai langkah terakhir berhasil
+		res, err := db.Collection("pekerjaan_alumni").UpdateMany(ctx,
+			bson.M{"alumni_id": objID, "deleted_with_alumni": true},
+			bson.M{
+				"$set":   bson.M{"is_deleted": false, "updated_at": now},
+				"$unset": bson.M{"deleted_with_alumni": "", "deleted_at": ""},
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("gagal memulihkan pekerjaan alumni: %v", err)
+		}
+		jobs = res.ModifiedCount
 
+		update := bson.M{
+			"$set":   bson.M{"is_deleted": false, "updated_at": now},
+			"$unset": bson.M{"deleted_at": "", "deleted_by": ""},
+		}
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
+		var alumni model.Alumni
+		err = db.Collection("alumni").FindOneAndUpdate(ctx, bson.M{"_id": objID, "is_deleted": true}, update, opts).Decode(&alumni)
+		if err == mongo.ErrNoDocuments {
+			return ErrAlumniNotInTrash
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return alumniWriteError(err)
+		}
+		if err != nil {
+			return fmt.Errorf("gagal memulihkan alumni: %v", err)
+		}
+		restored = &alumni
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return restored, jobs, nil
+}
+
+// PurgeAlumni menghapus permanen alumni yang ada di trash beserta semua pekerjaannya dan
+// metadata file milik akun yang tertaut. File fisik dikembalikan di hasil untuk dihapus dari
+// storage oleh pemanggil. Mengembalikan ErrAlumniNotInTrash jika alumni tidak ada di trash.
+func PurgeAlumni(db *mongo.Database, id string) (*model.AlumniPurgeResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	alumni, err := GetDeletedAlumniByID(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *model.AlumniPurgeResult
+	err = runInTransaction(ctx, db, func(ctx context.Context) error {
+		result = &model.AlumniPurgeResult{Files: []model.File{}}
+
+		// alumni dihapus paling akhir agar tanpa transaksi purge yang gagal bisa diulang
+		jobs, err := db.Collection("pekerjaan_alumni").DeleteMany(ctx, bson.M{"alumni_id": alumni.ID})
+		if err != nil {
+			return fmt.Errorf("gagal menghapus pekerjaan alumni: %v", err)
+		}
+		result.Pekerjaan = jobs.DeletedCount
+
+		if alumni.UserID != nil {
+			cursor, err := db.Collection("files").Find(ctx, bson.M{"user_id": *alumni.UserID})
+			if err != nil {
+				return err
+			}
+			if err := cursor.All(ctx, &result.Files); err != nil {
+				return err
+			}
+			if _, err := db.Collection("files").DeleteMany(ctx, bson.M{"user_id": *alumni.UserID}); err != nil {
+				return fmt.Errorf("gagal menghapus file alumni: %v", err)
+			}
+		}
+
+		res, err := db.Collection("alumni").DeleteOne(ctx, bson.M{"_id": alumni.ID, "is_deleted": true})
+		if err != nil {
+			return fmt.Errorf("gagal menghapus data: %v", err)
+		}
+		if res.DeletedCount == 0 {
+			return ErrAlumniNotInTrash
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// alumniJobJoinStages menggabungkan pekerjaan aktif (belum dihapus) dengan data alumninya
+func alumniJobJoinStages() []bson.M {
+	return []bson.M{
+		{"$match": bson.M{"is_deleted": false}},
+		{"$lookup": bson.M{
+			"from":         "alumni",
+			"localField":   "alumni_id",
+			"foreignField": "_id",
+			"as":           "alumni_info",
+		}},
+		{"$unwind": "$alumni_info"},
+		{"$match": bson.M{"alumni_info.is_deleted": bson.M{"$ne": true}}},
+	}
+}
+
+// GetAlumniWithHighSalary mengambil alumni dalam scope yang memiliki pekerjaan dengan gaji minimum di atas minGaji
+func GetAlumniWithHighSalary(db *mongo.Database, minGaji int64, mataUang string, scope model.JurusanScope) ([]model.AlumniWithSalary, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	match := withJurusanScope(bson.M{
+		"gaji_range.min":       bson.M{"$gt": minGaji},
+		"gaji_range.mata_uang": mataUang,
+	}, "alumni_info.jurusan", scope)
+
+	pipeline := append(alumniJobJoinStages(),
+		bson.M{"$match": match},
+		bson.M{"$project": bson.M{
+			"_id":             0,
+			"alumni_id":       "$alumni_info._id",
+			"nama_alumni":     "$alumni_info.nama",
+			"nim":             "$alumni_info.nim",
+			"jurusan":         "$alumni_info.jurusan",
+			"angkatan":        "$alumni_info.angkatan",
+			"nama_perusahaan": 1,
+			"posisi_jabatan":  1,
+			"gaji_range":      1,
+		}},
+		bson.M{"$sort": bson.M{"nama_alumni": 1}},
+	)
+
+	cursor, err := db.Collection("pekerjaan_alumni").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	results := []model.AlumniWithSalary{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetAllAlumniByYear mengambil alumni dalam scope yang lulus pada tahun tertentu
+func GetAllAlumniByYear(db *mongo.Database, year int, scope model.JurusanScope) ([]model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pipeline := []bson.M{
+		{"$match": withJurusanScope(activeAlumni(bson.M{"tahun_lulus": year}), "jurusan", scope)},
+		{"$sort": bson.M{"nama": 1}},
+	}
+
+	cursor, err := db.Collection("alumni").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	alumniList := []model.Alumni{}
+	if err := cursor.All(ctx, &alumniList); err != nil {
+		return nil, err
+	}
+	return alumniList, nil
+}
+
+// GetAlumniWithYear mengambil alumni dalam scope yang mulai bekerja di tahun yang sama dengan
+// tahun lulusnya. Jika year > 0, hasil dibatasi pada alumni yang lulus di tahun tersebut.
+func GetAlumniWithYear(db *mongo.Database, year int, scope model.JurusanScope) ([]model.AlumniWithYear, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	match := bson.M{
+		"$expr": bson.M{"$eq": bson.A{
+			"$alumni_info.tahun_lulus",
+			bson.M{"$year": "$tanggal_mulai_kerja"},
+		}},
+	}
+	if year > 0 {
+		match["alumni_info.tahun_lulus"] = year
+	}
+	match = withJurusanScope(match, "alumni_info.jurusan", scope)
+
+	pipeline := append(alumniJobJoinStages(),
+		bson.M{"$match": match},
+		bson.M{"$project": bson.M{
+			"_id":                 0,
+			"alumni_id":           "$alumni_info._id",
+			"nama_alumni":         "$alumni_info.nama",
+			"nim":                 "$alumni_info.nim",
+			"jurusan":             "$alumni_info.jurusan",
+			"angkatan":            "$alumni_info.angkatan",
+			"tahun_lulus":         "$alumni_info.tahun_lulus",
+			"tanggal_mulai_kerja": 1,
+			"nama_perusahaan":     1,
+			"posisi_jabatan":      1,
+			"gaji_range":          1,
+		}},
+		bson.M{"$sort": bson.M{"tanggal_mulai_kerja": 1}},
+	)
+
+	cursor, err := db.Collection("pekerjaan_alumni").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	results := []model.AlumniWithYear{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// FindAlumniByNIMs mengambil alumni aktif berdasarkan daftar NIM, dikembalikan dalam map NIM -> alumni
+func FindAlumniByNIMs(db *mongo.Database, nims []string) (map[string]model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result := make(map[string]model.Alumni, len(nims))
+	if len(nims) == 0 {
+		return result, nil
+	}
+
+	cursor, err := db.Collection("alumni").Find(ctx, activeAlumni(bson.M{"nim": bson.M{"$in": nims}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var a model.Alumni
+		if err := cursor.Decode(&a); err != nil {
+			return nil, err
+		}
+		result[a.NIM] = a
+	}
+	return result, cursor.Err()
+}
+
+// UpsertAlumniByNIM menyimpan banyak alumni sekaligus: alumni aktif dengan NIM yang sudah ada
+// diperbarui, selain itu dibuat baru. user_id tidak pernah diubah oleh proses ini, dan kolom
+// opsional yang kosong tidak menimpa nilai yang sudah tersimpan.
+func UpsertAlumniByNIM(db *mongo.Database, alumniList []model.Alumni) (inserted int, updated int, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	if len(alumniList) == 0 {
+		return 0, 0, nil
+	}
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(alumniList))
+	for _, a := range alumniList {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(activeAlumni(bson.M{"nim": a.NIM})).
+			SetUpdate(alumniImportUpdate(a, now)).
+			SetUpsert(true))
+	}
+
+	res, err := db.Collection("alumni").BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if res != nil {
+		inserted, updated = int(res.UpsertedCount), int(res.MatchedCount)
+	}
+	if err != nil {
+		return inserted, updated, fmt.Errorf("gagal menyimpan data import: %v", err)
+	}
+	return inserted, updated, nil
+}
+
+// alumniImportUpdate menyusun update upsert untuk satu alumni hasil import. Kolom opsional
+// yang kosong (tidak ada di file atau sel kosong) tidak menimpa nilai yang sudah tersimpan
+// dan hanya diisi nilai kosong saat alumni baru dibuat.
+func alumniImportUpdate(a model.Alumni, now time.Time) bson.M {
+	set := bson.M{
+		"nama":       a.Nama,
+		"jurusan":    a.Jurusan,
+		"angkatan":   a.Angkatan,
+		"updated_at": now,
+	}
+	onInsert := bson.M{
+		"user_id":    nil,
+		"created_at": now,
+		"is_deleted": false,
+	}
+
+	optional := func(field string, value interface{}, empty bool) {
+		if empty {
+			onInsert[field] = value
+		} else {
+			set[field] = value
+		}
+	}
+	optional("tahun_lulus", a.TahunLulus, a.TahunLulus == 0)
+	optional("email", a.Email, a.Email == "")
+	optional("no_telepon", a.NoTelp, a.NoTelp == nil)
+	optional("alamat", a.Alamat, a.Alamat == nil)
+
+	return bson.M{"$set": set, "$setOnInsert": onInsert}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestAlumniImportUpdate_EmptyOptionalFieldsNotOverwritten(t *testing.T) {
+	update := alumniImportUpdate(model.Alumni{NIM: "187221002", Nama: "Sari", Jurusan: "Informatika", Angkatan: 2018}, time.Now())
+
+	set := update["$set"].(bson.M)
+	onInsert := update["$setOnInsert"].(bson.M)
+	for _, field := range []string{"tahun_lulus", "email", "no_telepon", "alamat"} {
+		if _, ok := set[field]; ok {
+			t.Errorf("%s kosong tidak boleh di-$set", field)
+		}
+		if _, ok := onInsert[field]; !ok {
+			t.Errorf("%s harus diisi saat insert", field)
+		}
+	}
+	if set["nama"] != "Sari" || set["jurusan"] != "Informatika" {
+		t.Errorf("unexpected $set: %v", set)
+	}
+}
+
+func TestAlumniImportUpdate_FilledOptionalFieldsSet(t *testing.T) {
+	telp := "08123456789"
+	update := alumniImportUpdate(model.Alumni{NIM: "187221002", Nama: "Sari", Email: "sari@mail.com", NoTelp: &telp, TahunLulus: 2022}, time.Now())
+
+	set := update["$set"].(bson.M)
+	onInsert := update["$setOnInsert"].(bson.M)
+	for _, field := range []string{"tahun_lulus", "email", "no_telepon"} {
+		if _, ok := set[field]; !ok {
+			t.Errorf("%s harus di-$set", field)
+		}
+		if _, ok := onInsert[field]; ok {
+			t.Errorf("%s tidak boleh ada di $set dan $setOnInsert sekaligus", field)
+		}
+	}
+}
+
+func TestCreateAlumni_DuplicateKeyIsConflict(t *testing.T) {
+	mt := newMockDB(t)
+
+	tests := []struct {
+		name  string
+		index string
+		want  error
+	}{
+		{"nim", alumniNIMIndex, ErrAlumniNIMTaken},
+		{"user_id", alumniUserIndex, ErrAlumniProfileExists},
+	}
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
+				Code:    11000,
+				Message: "E11000 duplicate key error collection: db.alumni index: " + tt.index,
+			}))
+
+			_, err := CreateAlumni(mt.DB, &model.Alumni{NIM: "187221001", Nama: "Sari"}, nil)
+			if !errors.Is(err, tt.want) {
+				mt.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
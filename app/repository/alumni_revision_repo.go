@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// revisionInsertAttempts membatasi percobaan ulang saat dua perubahan bersamaan
+// mendapat nomor versi yang sama
+const revisionInsertAttempts = 5
+
+// EnsureAlumniRevisionIndexes membuat index unik alumni_id + version agar nomor versi tidak ganda
+func EnsureAlumniRevisionIndexes(db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := db.Collection("alumni_revisions").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "alumni_id", Value: 1}, {Key: "version", Value: -1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("gagal membuat index alumni_revisions: %v", err)
+	}
+	return nil
+}
+
+// latestAlumniVersion mengembalikan nomor versi terakhir alumni, 0 jika belum ada revisi
+func latestAlumniVersion(ctx context.Context, db *mongo.Database, alumniID primitive.ObjectID) (int, error) {
+	var latest model.AlumniRevision
+	err := db.Collection("alumni_revisions").
+		FindOne(ctx, bson.M{"alumni_id": alumniID}, options.FindOne().SetSort(bson.M{"version": -1})).
+		Decode(&latest)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return latest.Version, nil
+}
+
+// CreateAlumniRevision menyimpan revisi dengan nomor versi berikutnya. Version pada rev
+// diisi oleh fungsi ini.
+func CreateAlumniRevision(db *mongo.Database, rev *model.AlumniRevision) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for attempt := 0; attempt < revisionInsertAttempts; attempt++ {
+		latest, err := latestAlumniVersion(ctx, db, rev.AlumniID)
+		if err != nil {
+			return err
+		}
+
+		rev.ID = primitive.NewObjectID()
+		rev.Version = latest + 1
+		_, err = db.Collection("alumni_revisions").InsertOne(ctx, rev)
+		if err == nil {
+			return nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("gagal menentukan versi revisi alumni %s", rev.AlumniID.Hex())
+}
+
+// GetLatestAlumniRevision mengambil revisi terakhir alumni, nil jika belum ada
+func GetLatestAlumniRevision(db *mongo.Database, alumniID primitive.ObjectID) (*model.AlumniRevision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var rev model.AlumniRevision
+	err := db.Collection("alumni_revisions").
+		FindOne(ctx, bson.M{"alumni_id": alumniID}, options.FindOne().SetSort(bson.M{"version": -1})).
+		Decode(&rev)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// GetAlumniRevision mengambil satu revisi alumni berdasarkan nomor versi, nil jika tidak ada
+func GetAlumniRevision(db *mongo.Database, alumniID primitive.ObjectID, version int) (*model.AlumniRevision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var rev model.AlumniRevision
+	err := db.Collection("alumni_revisions").
+		FindOne(ctx, bson.M{"alumni_id": alumniID, "version": version}).
+		Decode(&rev)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// GetAlumniRevisions mengambil riwayat revisi alumni, versi terbaru lebih dulu kecuali order asc
+func GetAlumniRevisions(db *mongo.Database, alumniID primitive.ObjectID, order string, limit, offset int) ([]model.AlumniRevision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	sortOrder := -1
+	if order == "asc" {
+		sortOrder = 1
+	}
+
+	opts := options.Find().
+		SetSort(bson.M{"version": sortOrder}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := db.Collection("alumni_revisions").Find(ctx, bson.M{"alumni_id": alumniID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	revisions := []model.AlumniRevision{}
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// CountAlumniRevisions menghitung jumlah revisi alumni
+func CountAlumniRevisions(db *mongo.Database, alumniID primitive.ObjectID) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	total, err := db.Collection("alumni_revisions").CountDocuments(ctx, bson.M{"alumni_id": alumniID})
+	if err != nil {
+		return 0, err
+	}
+	return int(total), nil
+}
+
+// RestoreAlumniSnapshot mengembalikan field data alumni ke isi snapshot. Jika alumni sudah
+// dihapus, dokumen dibuat ulang dengan ID yang sama. user_id dan created_at dokumen yang masih
+// ada tidak diubah karena bukan bagian dari data yang diedit admin.
+func RestoreAlumniSnapshot(db *mongo.Database, snapshot model.Alumni) (*model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"nim":         snapshot.NIM,
+			"nama":        snapshot.Nama,
+			"jurusan":     snapshot.Jurusan,
+			"angkatan":    snapshot.Angkatan,
+			"tahun_lulus": snapshot.TahunLulus,
+			"email":       snapshot.Email,
+			"no_telepon":  snapshot.NoTelp,
+			"alamat":      snapshot.Alamat,
+			"updated_at":  time.Now(),
+		},
+		"$setOnInsert": bson.M{
+			"user_id":    snapshot.UserID,
+			"created_at": snapshot.CreatedAt,
+			"is_deleted": false,
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var restored model.Alumni
+	err := db.Collection("alumni").
+		FindOneAndUpdate(ctx, bson.M{"_id": snapshot.ID}, update, opts).
+		Decode(&restored)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengembalikan data alumni: %v", err)
+	}
+	return &restored, nil
+}
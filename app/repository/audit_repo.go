@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditRepository menyimpan audit log; dipakai service berbasis struct seperti FileService
+type AuditRepository interface {
+	Create(entry *model.AuditLog) error
+}
+
+type auditRepository struct {
+	db *mongo.Database
+}
+
+func NewAuditRepository(db *mongo.Database) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Create(entry *model.AuditLog) error {
+	return CreateAuditLog(r.db, entry)
+}
+
+// EnsureAuditIndexes membuat index untuk query audit log yang umum dipakai
+func EnsureAuditIndexes(db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := db.Collection("audit_logs").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "timestamp", Value: -1}}},
+		{Keys: bson.D{{Key: "entity_type", Value: 1}, {Key: "entity_id", Value: 1}, {Key: "timestamp", Value: -1}}},
+		{Keys: bson.D{{Key: "actor", Value: 1}, {Key: "timestamp", Value: -1}}},
+	})
+	if err != nil {
+		return fmt.Errorf("gagal membuat index audit_logs: %v", err)
+	}
+	return nil
+}
+
+func CreateAuditLog(db *mongo.Database, entry *model.AuditLog) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := db.Collection("audit_logs").InsertOne(ctx, entry)
+	if err != nil {
+		return err
+	}
+	entry.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// auditFilterQuery menerjemahkan AuditFilter menjadi filter MongoDB
+func auditFilterQuery(f model.AuditFilter) bson.M {
+	filter := bson.M{}
+
+	if f.Actor != "" {
+		if id, err := primitive.ObjectIDFromHex(f.Actor); err == nil {
+			filter["actor_id"] = id
+		} else {
+			filter["actor"] = exactInsensitive(f.Actor)
+		}
+	}
+	if f.Action != "" {
+		filter["action"] = f.Action
+	}
+	if f.EntityType != "" {
+		filter["entity_type"] = f.EntityType
+	}
+	if f.EntityID != "" {
+		filter["entity_id"] = f.EntityID
+	}
+
+	timeRange := bson.M{}
+	if f.From != nil {
+		timeRange["$gte"] = *f.From
+	}
+	if f.To != nil {
+		timeRange["$lte"] = *f.To
+	}
+	if len(timeRange) > 0 {
+		filter["timestamp"] = timeRange
+	}
+
+	return filter
+}
+
+// GetAuditLogs mengambil audit log terbaru lebih dulu dengan filter dan pagination
+func GetAuditLogs(db *mongo.Database, f model.AuditFilter, order string, limit, offset int) ([]model.AuditLog, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	sortOrder := -1
+	if order == "asc" {
+		sortOrder = 1
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: sortOrder}, {Key: "_id", Value: sortOrder}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := db.Collection("audit_logs").Find(ctx, auditFilterQuery(f), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	logs := []model.AuditLog{}
+	if err := cursor.All(ctx, &logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// CountAuditLogs menghitung audit log yang cocok dengan filter
+func CountAuditLogs(db *mongo.Database, f model.AuditFilter) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	total, err := db.Collection("audit_logs").CountDocuments(ctx, auditFilterQuery(f))
+	if err != nil {
+		return 0, err
+	}
+	return int(total), nil
+}
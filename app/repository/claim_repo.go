@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrAlumniAlreadyClaimed dikembalikan jika data alumni sudah tertaut ke user lain saat akan diklaim
+var ErrAlumniAlreadyClaimed = model.NewConflictError("data alumni sudah tertaut ke akun lain")
+
+// MaxClaimCodeAttempts adalah jumlah percobaan kode yang salah sebelum kode klaim hangus
+const MaxClaimCodeAttempts = 5
+
+// EnsureClaimIndexes membuat index unik parsial nim pada klaim yang masih pending, sehingga
+// registrasi berulang untuk NIM yang sama tidak menumpuk antrean pemeriksaan admin
+func EnsureClaimIndexes(db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := db.Collection("alumni_claim_reviews").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "nim", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"status": model.ClaimReviewPending}),
+	})
+	if err != nil {
+		return fmt.Errorf("gagal membuat index alumni_claim_reviews: %v", err)
+	}
+	return nil
+}
+
+// FindAlumniByNIM mengambil semua data alumni aktif (tidak di trash) dengan NIM tertentu.
+// Lebih dari satu hasil berarti ada data ganda.
+func FindAlumniByNIM(db *mongo.Database, nim string) ([]model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := db.Collection("alumni").Find(ctx, activeAlumni(bson.M{"nim": nim}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var alumni []model.Alumni
+	if err := cursor.All(ctx, &alumni); err != nil {
+		return nil, err
+	}
+	return alumni, nil
+}
+
+// CreateClaimCode menyimpan kode klaim baru dan menghanguskan kode lama yang belum terpakai
+func CreateClaimCode(db *mongo.Database, code *model.AlumniClaimCode) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	_, err := db.Collection("alumni_claim_codes").UpdateMany(ctx,
+		bson.M{"alumni_id": code.AlumniID, "used_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"expires_at": now}},
+	)
+	if err != nil {
+		return err
+	}
+
+	code.ID = primitive.NewObjectID()
+	code.CreatedAt = now
+	_, err = db.Collection("alumni_claim_codes").InsertOne(ctx, code)
+	return err
+}
+
+// VerifyClaimCode mencocokkan hash kode dengan kode klaim aktif milik alumni. Percobaan yang
+// salah dihitung; setelah MaxClaimCodeAttempts kode tidak bisa dipakai lagi.
+// Mengembalikan ID kode jika cocok.
+func VerifyClaimCode(db *mongo.Database, alumniID primitive.ObjectID, codeHash string) (*primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"alumni_id":  alumniID,
+		"used_at":    bson.M{"$exists": false},
+		"expires_at": bson.M{"$gt": time.Now()},
+		"attempts":   bson.M{"$lt": MaxClaimCodeAttempts},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	var code model.AlumniClaimCode
+	err := db.Collection("alumni_claim_codes").FindOne(ctx, filter, opts).Decode(&code)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(code.CodeHash), []byte(codeHash)) != 1 {
+		_, err := db.Collection("alumni_claim_codes").UpdateOne(ctx,
+			bson.M{"_id": code.ID},
+			bson.M{"$inc": bson.M{"attempts": 1}},
+		)
+		return nil, err
+	}
+	return &code.ID, nil
+}
+
+func markClaimCodeUsed(ctx context.Context, db *mongo.Database, id primitive.ObjectID) error {
+	_, err := db.Collection("alumni_claim_codes").UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"used_at": time.Now()}},
+	)
+	return err
+}
+
+// CreateClaimReview mencatat klaim NIM yang perlu diperiksa admin. Hanya satu klaim pending
+// per NIM yang disimpan; false berarti NIM tersebut sudah menunggu pemeriksaan.
+func CreateClaimReview(db *mongo.Database, review *model.AlumniClaimReview) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	review.ID = primitive.NewObjectID()
+	review.Status = model.ClaimReviewPending
+	review.CreatedAt = time.Now()
+
+	_, err := db.Collection("alumni_claim_reviews").InsertOne(ctx, review)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func claimReviewFilter(status string) bson.M {
+	if status == "" {
+		return bson.M{}
+	}
+	return bson.M{"status": status}
+}
+
+// GetClaimReviews mengambil daftar klaim NIM, terbaru lebih dulu
+func GetClaimReviews(db *mongo.Database, status string, limit, offset int) ([]model.AlumniClaimReview, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := db.Collection("alumni_claim_reviews").Find(ctx, claimReviewFilter(status), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	reviews := []model.AlumniClaimReview{}
+	if err := cursor.All(ctx, &reviews); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func CountClaimReviews(db *mongo.Database, status string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	total, err := db.Collection("alumni_claim_reviews").CountDocuments(ctx, claimReviewFilter(status))
+	return int(total), err
+}
+
+// ResolveClaimReview menyimpan keputusan admin untuk klaim yang masih pending.
+// Mengembalikan nil jika klaim tidak ditemukan atau sudah diputuskan.
+func ResolveClaimReview(db *mongo.Database, id primitive.ObjectID, status, catatan, resolvedBy string) (*model.AlumniClaimReview, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var review model.AlumniClaimReview
+	err := db.Collection("alumni_claim_reviews").FindOneAndUpdate(ctx,
+		bson.M{"_id": id, "status": model.ClaimReviewPending},
+		bson.M{"$set": bson.M{
+			"status":      status,
+			"catatan":     catatan,
+			"resolved_by": resolvedBy,
+			"resolved_at": now,
+		}},
+		opts,
+	).Decode(&review)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
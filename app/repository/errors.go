@@ -0,0 +1,23 @@
+package repository
+
+import "github.com/noorfarihaf11/clean-arc/app/model"
+
+// Error not found dari repository. Dikembalikan apa adanya sehingga handler cukup meneruskannya
+// ke error handler (404), atau memeriksanya dengan errors.Is jika butuh pesan lain.
+var (
+	ErrAlumniNotFound   = model.NewNotFoundError("Alumni tidak ditemukan")
+	ErrAlumniNotInTrash = model.NewNotFoundError("Alumni tidak ditemukan di trash")
+	ErrJobNotFound      = model.NewNotFoundError("Pekerjaan tidak ditemukan")
+	ErrJobNotInTrash    = model.NewNotFoundError("Pekerjaan tidak ditemukan di trash")
+	ErrFileNotFound     = model.NewNotFoundError("File not found")
+)
+
+// ErrJobAlumniInTrash dikembalikan saat memulihkan pekerjaan yang alumninya masih di trash;
+// pekerjaan aktif milik alumni di trash tidak akan terlihat di listing mana pun
+var ErrJobAlumniInTrash = model.NewConflictError("Alumni pemilik pekerjaan ada di trash, restore alumni terlebih dahulu")
+
+// Pelanggaran index unik alumni: NIM dipakai alumni aktif lain, atau akun sudah punya profil
+var (
+	ErrAlumniNIMTaken      = model.NewConflictError("NIM sudah dipakai data alumni lain")
+	ErrAlumniProfileExists = model.NewConflictError("Akun sudah memiliki profil alumni")
+)
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// exportTimeout adalah batas waktu satu proses export
+const exportTimeout = 5 * time.Minute
+
+func exportSort(sortBy, order string) bson.D {
+	sortOrder := 1
+	if order == "desc" {
+		sortOrder = -1
+	}
+	sort := bson.D{{Key: sortBy, Value: sortOrder}}
+	if sortBy != "_id" {
+		sort = append(sort, bson.E{Key: "_id", Value: sortOrder})
+	}
+	return sort
+}
+
+// StreamAlumni membaca alumni yang cocok dengan pencarian satu per satu lewat cursor
+// dan memanggil fn untuk setiap dokumen, tanpa memuat seluruh koleksi ke memori.
+func StreamAlumni(db *mongo.Database, search, sortBy, order string, fn func(model.Alumni) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(exportSort(sortBy, order))
+	cursor, err := db.Collection("alumni").Find(ctx, activeAlumni(alumniSearchFilter(search)), opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var a model.Alumni
+		if err := cursor.Decode(&a); err != nil {
+			return err
+		}
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// StreamJobs membaca pekerjaan yang cocok dengan filter satu per satu lewat cursor.
+// Jika withAlumni true, data alumni setiap pekerjaan ikut digabungkan lewat $lookup.
+func StreamJobs(db *mongo.Database, f model.JobFilter, sortBy, order string, withAlumni bool, fn func(model.PekerjaanWithAlumni) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	pipeline := []bson.M{
+		{"$match": jobFilterQuery(f)},
+		{"$sort": exportSort(sortBy, order)},
+	}
+	if withAlumni {
+		pipeline = append(pipeline,
+			bson.M{"$lookup": bson.M{
+				"from":         "alumni",
+				"localField":   "alumni_id",
+				"foreignField": "_id",
+				"as":           "alumni",
+			}},
+			bson.M{"$unwind": bson.M{"path": "$alumni", "preserveNullAndEmptyArrays": true}},
+		)
+	}
+
+	cursor, err := db.Collection("pekerjaan_alumni").Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var job model.PekerjaanWithAlumni
+		if err := cursor.Decode(&job); err != nil {
+			return err
+		}
+		if err := fn(job); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
@@ -7,12 +7,16 @@ import (
 "go.mongodb.org/mongo-driver/bson"
 "go.mongodb.org/mongo-driver/bson/primitive"
 "go.mongodb.org/mongo-driver/mongo"
+"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type FileRepository interface {
 	Create(file *model.File) error
 	FindAll() ([]model.File, error)
 	FindByID(id string) (*model.File, error)
+	FindByUserID(userID primitive.ObjectID) ([]model.File, error)
+	FindByJurusan(scope model.JurusanScope) ([]model.File, error)
+	OwnerInJurusan(userID primitive.ObjectID, scope model.JurusanScope) (bool, error)
 	Delete(id string) error
 }
 
@@ -58,17 +62,93 @@ func (r *fileRepository) FindAll() ([]model.File, error) {
 	return files, nil
 }
 
+func (r *fileRepository) FindByUserID(userID primitive.ObjectID) ([]model.File, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "uploaded_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	files := []model.File{}
+	if err = cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// jurusanUserIDs mengambil user_id akun yang tertaut ke alumni pada jurusan dalam scope
+func (r *fileRepository) jurusanUserIDs(ctx context.Context, scope model.JurusanScope) ([]primitive.ObjectID, error) {
+	filter := withJurusanScope(activeAlumni(bson.M{"user_id": bson.M{"$ne": nil}}), "jurusan", scope)
+	values, err := r.collection.Database().Collection("alumni").Distinct(ctx, "user_id", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []primitive.ObjectID{}
+	for _, v := range values {
+		if id, ok := v.(primitive.ObjectID); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// FindByJurusan mengambil file milik alumni pada jurusan dalam scope, terbaru lebih dulu
+func (r *fileRepository) FindByJurusan(scope model.JurusanScope) ([]model.File, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userIDs, err := r.jurusanUserIDs(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "uploaded_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": bson.M{"$in": userIDs}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	files := []model.File{}
+	if err = cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// OwnerInJurusan memeriksa apakah user pemilik file tertaut ke alumni pada jurusan dalam scope
+func (r *fileRepository) OwnerInJurusan(userID primitive.ObjectID, scope model.JurusanScope) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := withJurusanScope(activeAlumni(bson.M{"user_id": userID}), "jurusan", scope)
+	count, err := r.collection.Database().Collection("alumni").CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 func (r *fileRepository) FindByID(id string) (*model.File, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-	return nil, err
+	return nil, model.NewValidationError("ID file tidak valid")
 	}
 
 	var file model.File
 	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&file)
+	if err == mongo.ErrNoDocuments {
+	return nil, ErrFileNotFound
+	}
 	if err != nil {
 	return nil, err
 	}
@@ -82,7 +162,7 @@ func (r *fileRepository) Delete(id string) error {
 
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-	return err
+	return model.NewValidationError("ID file tidak valid")
 	}
 	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
 	return err
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AcquireLease mencoba mengambil atau memperpanjang lease bernama name untuk holder selama
+// ttl. Lease dipakai agar pekerjaan terjadwal hanya dijalankan satu instance server pada satu
+// waktu. false berarti lease masih dipegang instance lain. Waktu lease memakai jam database
+// ($$NOW) agar selisih jam antar instance tidak membuat lease diambil alih terlalu cepat.
+func AcquireLease(db *mongo.Database, name, holder string, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"_id": name,
+		"$or": []bson.M{
+			{"holder": holder},
+			{"$expr": bson.M{"$lte": bson.A{"$expires_at", "$$NOW"}}},
+		},
+	}
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"holder":      holder,
+		"acquired_at": "$$NOW",
+		"expires_at":  bson.M{"$add": bson.A{"$$NOW", ttl.Milliseconds()}},
+	}}}}
+
+	// upsert membuat dokumen lease pertama kali; jika dokumen sudah ada tetapi dipegang
+	// instance lain, filter tidak cocok dan upsert gagal karena _id duplikat
+	_, err := db.Collection("leases").UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
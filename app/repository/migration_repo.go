@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MigrateGajiRange mengubah gaji_range lama (teks bebas) menjadi bentuk terstruktur {min, max, mata_uang}.
+// Nilai yang tidak bisa di-parse dikosongkan dan teks aslinya disimpan di gaji_range_raw.
+// Aman dijalankan berulang kali karena hanya menyentuh dokumen yang gaji_range-nya masih string.
+func MigrateGajiRange(db *mongo.Database) (converted int, failed int, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	collection := db.Collection("pekerjaan_alumni")
+	cursor, err := collection.Find(ctx, bson.M{"gaji_range": bson.M{"$type": "string"}})
+	if err != nil {
+		return 0, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID        primitive.ObjectID `bson:"_id"`
+			GajiRange string             `bson:"gaji_range"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return converted, failed, err
+		}
+
+		set := bson.M{}
+		gaji, parseErr := model.ParseGajiRange(doc.GajiRange)
+		if parseErr != nil {
+			log.Printf("Migrasi gaji_range: pekerjaan %s tidak bisa di-parse (%q): %v", doc.ID.Hex(), doc.GajiRange, parseErr)
+			set["gaji_range"] = nil
+			set["gaji_range_raw"] = doc.GajiRange
+			failed++
+		} else {
+			set["gaji_range"] = gaji
+			converted++
+		}
+
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": doc.ID}, bson.M{"$set": set}); err != nil {
+			return converted, failed, err
+		}
+	}
+
+	return converted, failed, cursor.Err()
+}
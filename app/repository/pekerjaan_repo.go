@@ -3,36 +3,111 @@ package repository
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/noorfarihaf11/clean-arc/app/model"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func GetAllJobs(db *mongo.Database) ([]model.PekerjaanAlumni, error) {
+// jobFilterQuery menerjemahkan JobFilter menjadi filter MongoDB untuk pekerjaan yang belum dihapus
+func jobFilterQuery(f model.JobFilter) bson.M {
+	filter := bson.M{"is_deleted": false}
+
+	if f.Search != "" {
+		regex := primitive.Regex{Pattern: regexp.QuoteMeta(f.Search), Options: "i"}
+		filter["$or"] = []bson.M{
+			{"nama_perusahaan": regex},
+			{"posisi_jabatan": regex},
+		}
+	}
+	if f.BidangIndustri != "" {
+		filter["bidang_industri"] = exactInsensitive(f.BidangIndustri)
+	}
+	if f.LokasiKerja != "" {
+		filter["lokasi_kerja"] = exactInsensitive(f.LokasiKerja)
+	}
+	if f.StatusPekerjaan != "" {
+		filter["status_pekerjaan"] = exactInsensitive(f.StatusPekerjaan)
+	}
+
+	dateRange := bson.M{}
+	if f.MulaiDari != nil {
+		dateRange["$gte"] = *f.MulaiDari
+	}
+	if f.MulaiSampai != nil {
+		dateRange["$lte"] = *f.MulaiSampai
+	}
+	if len(dateRange) > 0 {
+		filter["tanggal_mulai_kerja"] = dateRange
+	}
+
+	if f.Scope != nil {
+		filter = withJobScope(filter, *f.Scope)
+	}
+
+	return filter
+}
+
+// exactInsensitive mencocokkan nilai secara utuh tanpa membedakan huruf besar/kecil
+func exactInsensitive(value string) primitive.Regex {
+	return primitive.Regex{Pattern: "^" + regexp.QuoteMeta(value) + "$", Options: "i"}
+}
+
+// GetJobsRepo mengambil pekerjaan alumni dengan filter, sorting, dan pagination
+func GetJobsRepo(db *mongo.Database, f model.JobFilter, sortBy, order string, limit, offset int) ([]model.PekerjaanAlumni, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	cur, err := db.Collection("pekerjaan_alumni").Find(ctx, bson.M{"is_deleted": false})
+	sortOrder := 1
+	if order == "desc" {
+		sortOrder = -1
+	}
+
+	sort := bson.D{{Key: sortBy, Value: sortOrder}}
+	if sortBy != "_id" {
+		sort = append(sort, bson.E{Key: "_id", Value: sortOrder})
+	}
+
+	opts := options.Find().
+		SetSort(sort).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cur, err := db.Collection("pekerjaan_alumni").Find(ctx, jobFilterQuery(f), opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cur.Close(ctx)
 
-	var jobs []model.PekerjaanAlumni
+	jobs := []model.PekerjaanAlumni{}
 	err = cur.All(ctx, &jobs)
 	return jobs, err
 }
 
+// CountJobsRepo menghitung total pekerjaan yang cocok dengan filter
+func CountJobsRepo(db *mongo.Database, f model.JobFilter) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	total, err := db.Collection("pekerjaan_alumni").CountDocuments(ctx, jobFilterQuery(f))
+	if err != nil {
+		return 0, err
+	}
+	return int(total), nil
+}
+
+// GetJobByID mengambil pekerjaan aktif, ErrJobNotFound jika tidak ada
 func GetJobByID(db *mongo.Database, id string) (*model.PekerjaanAlumni, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, fmt.Errorf("ID tidak valid: %v", err)
+		return nil, model.NewValidationError("ID tidak valid")
 	}
 
 	var job model.PekerjaanAlumni
@@ -41,18 +116,49 @@ func GetJobByID(db *mongo.Database, id string) (*model.PekerjaanAlumni, error) {
 		Decode(&job)
 
 	if err == mongo.ErrNoDocuments {
-		return nil, nil
+		return nil, ErrJobNotFound
 	}
 	return &job, err
 }
 
+// individuallyTrashed membatasi filter ke pekerjaan di trash yang dihapus sendiri. Pekerjaan
+// yang terhapus bersama alumninya dikelola lewat trash alumni.
+func individuallyTrashed(filter bson.M) bson.M {
+	filter["is_deleted"] = true
+	filter["deleted_with_alumni"] = bson.M{"$ne": true}
+	return filter
+}
+
+// GetTrashedJobByID mengambil pekerjaan yang sudah ada di trash, ErrJobNotInTrash jika tidak ada
+func GetTrashedJobByID(db *mongo.Database, id string) (*model.PekerjaanAlumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, model.NewValidationError("ID tidak valid")
+	}
+
+	var job model.PekerjaanAlumni
+	err = db.Collection("pekerjaan_alumni").
+		FindOne(ctx, individuallyTrashed(bson.M{"_id": objID})).
+		Decode(&job)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrJobNotInTrash
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
 func GetJobsByAlumniID(db *mongo.Database, alumniID string) ([]model.PekerjaanAlumni, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	aid, err := primitive.ObjectIDFromHex(alumniID)
 	if err != nil {
-		return nil, fmt.Errorf("ID alumni tidak valid")
+		return nil, model.NewValidationError("ID alumni tidak valid")
 	}
 
 	cur, err := db.Collection("pekerjaan_alumni").Find(ctx, bson.M{"alumni_id": aid, "is_deleted": false})
@@ -67,18 +173,31 @@ func GetJobsByAlumniID(db *mongo.Database, alumniID string) ([]model.PekerjaanAl
 }
 
 
+// withJobScope membatasi filter ke pekerjaan milik alumni dalam scope jika scope tidak
+// mencakup semua pekerjaan
+func withJobScope(filter bson.M, scope model.JobScope) bson.M {
+	if scope.Scope != model.ScopeAll {
+		ids := scope.AlumniIDs
+		if ids == nil {
+			ids = []primitive.ObjectID{}
+		}
+		filter["alumni_id"] = bson.M{"$in": ids}
+	}
+	return filter
+}
+
 func CreateJob(db *mongo.Database, job *model.PekerjaanAlumni) (*model.PekerjaanAlumni, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	id, err := primitive.ObjectIDFromHex(job.AlumniIDStr)
 	if err != nil {
-		return nil, fmt.Errorf("alumni_id tidak valid")
+		return nil, model.NewValidationError("alumni_id tidak valid")
 	}
 	job.AlumniID = id
 	job.ID = primitive.NewObjectID()
 	job.CreatedAt, job.UpdatedAt = time.Now(), time.Now()
-	job.IsDeleted = false
+	job.IsDeleted, job.DeletedAt, job.DeletedWithAlumni = false, nil, false
 
 	if _, err := db.Collection("pekerjaan_alumni").InsertOne(ctx, job); err != nil {
 		return nil, err
@@ -86,34 +205,43 @@ func CreateJob(db *mongo.Database, job *model.PekerjaanAlumni) (*model.Pekerjaan
 	return job, nil
 }
 
-func UpdateJob(db *mongo.Database, id string, data model.PekerjaanAlumni) (*model.PekerjaanAlumni, error) {
+// UpdateJob memperbarui pekerjaan dalam scope. alumni_id hanya diubah jika dikirim.
+// Pekerjaan di luar scope dianggap tidak ditemukan (ErrJobNotFound).
+func UpdateJob(db *mongo.Database, id string, data model.PekerjaanAlumni, scope model.JobScope) (*model.PekerjaanAlumni, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, fmt.Errorf("ID tidak valid")
-	}
-	if data.AlumniIDStr != "" {
-		if data.AlumniID, err = primitive.ObjectIDFromHex(data.AlumniIDStr); err != nil {
-			return nil, fmt.Errorf("alumni_id tidak valid")
-		}
+		return nil, model.NewValidationError("ID tidak valid")
 	}
 
 	data.UpdatedAt = time.Now()
-	update := bson.M{"$set": bson.M{
-		"alumni_id": data.AlumniID, "nama_perusahaan": data.NamaPerusahaan,
+	set := bson.M{
+		"nama_perusahaan": data.NamaPerusahaan,
 		"posisi_jabatan": data.PosisiJabatan, "bidang_industri": data.BidangIndustri,
 		"lokasi_kerja": data.LokasiKerja, "gaji_range": data.GajiRange,
 		"tanggal_mulai_kerja": data.TanggalMulaiKerja, "tanggal_selesai_kerja": data.TanggalSelesaiKerja,
 		"status_pekerjaan": data.StatusPekerjaan, "deskripsi_pekerjaan": data.DeskripsiPekerjaan,
 		"updated_at": data.UpdatedAt,
-	}}
+	}
+	if data.AlumniIDStr != "" {
+		if data.AlumniID, err = primitive.ObjectIDFromHex(data.AlumniIDStr); err != nil {
+			return nil, model.NewValidationError("alumni_id tidak valid")
+		}
+		set["alumni_id"] = data.AlumniID
+	}
+	update := bson.M{"$set": set}
+	if data.GajiRange != nil {
+		// gaji sudah terstruktur, teks lama hasil migrasi tidak diperlukan lagi
+		update["$unset"] = bson.M{"gaji_range_raw": ""}
+	}
 
-	if r, err := db.Collection("pekerjaan_alumni").UpdateOne(ctx, bson.M{"_id": objID, "is_deleted": false}, update); err != nil {
+	filter := withJobScope(bson.M{"_id": objID, "is_deleted": false}, scope)
+	if r, err := db.Collection("pekerjaan_alumni").UpdateOne(ctx, filter, update); err != nil {
 		return nil, err
 	} else if r.MatchedCount == 0 {
-		return nil, fmt.Errorf("data tidak ditemukan")
+		return nil, ErrJobNotFound
 	}
 
 	var updated model.PekerjaanAlumni
@@ -123,29 +251,25 @@ func UpdateJob(db *mongo.Database, id string, data model.PekerjaanAlumni) (*mode
 	return &updated, nil
 }
 
-func SoftDeleteJob(db *mongo.Database, id string, userID string, role string) error {
+// SoftDeleteJob memindahkan pekerjaan dalam scope ke trash. Pekerjaan yang tidak ditemukan
+// atau di luar scope menghasilkan ErrJobNotFound.
+func SoftDeleteJob(db *mongo.Database, id string, scope model.JobScope) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return fmt.Errorf("ID pekerjaan tidak valid: %v", err)
+		return model.NewValidationError("ID pekerjaan tidak valid")
 	}
 
-	filter := bson.M{"_id": objID, "is_deleted": false}
-
-	if role != "admin" {
-		alumniID, err := primitive.ObjectIDFromHex(userID)
-		if err != nil {
-			return fmt.Errorf("alumni_id tidak valid: %v", err)
-		}
-		filter["alumni_id"] = alumniID
-	}
+	filter := withJobScope(bson.M{"_id": objID, "is_deleted": false}, scope)
 
+	now := time.Now()
 	update := bson.M{
 		"$set": bson.M{
 			"is_deleted": true,
-			"updated_at": time.Now(),
+			"deleted_at": now,
+			"updated_at": now,
 		},
 	}
 
@@ -154,9 +278,8 @@ func SoftDeleteJob(db *mongo.Database, id string, userID string, role string) er
 		return fmt.Errorf("gagal menghapus data: %v", err)
 	}
 	if result.MatchedCount == 0 {
-		return fmt.Errorf("tidak diizinkan menghapus pekerjaan ini atau data tidak ditemukan")
+		return ErrJobNotFound
 	}
-
 	return nil
 }
 
@@ -166,7 +289,7 @@ func GetTotalJobAlumni(db *mongo.Database, alumniID string) ([]model.TotalJobAlu
 
 	aid, err := primitive.ObjectIDFromHex(alumniID)
 	if err != nil {
-		return nil, err
+		return nil, model.NewValidationError("ID alumni tidak valid")
 	}
 
 	// Aggregation pipeline: match + lookup + group + count
@@ -213,81 +336,268 @@ func GetTotalJobAlumni(db *mongo.Database, alumniID string) ([]model.TotalJobAlu
 	return results, nil
 }
 
-// GetTrash ambil semua pekerjaan yang dihapus (soft delete)
-func GetTrash(db *mongo.Database, userID string, role string) ([]model.Trash, error) {
+// GetTrash mengambil pekerjaan di trash dalam scope beserta nama alumninya dalam satu
+// aggregation, dengan pencarian nama perusahaan atau nama alumni, sorting, dan pagination.
+// Mengembalikan data halaman saat ini dan total pekerjaan yang cocok.
+func GetTrash(db *mongo.Database, scope model.JobScope, search, sortBy, order string, limit, offset int) ([]model.Trash, int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	filter := bson.M{"is_deleted": true}
-	if role != "admin" {
-		uid, err := primitive.ObjectIDFromHex(userID)
-		if err != nil {
-			return nil, err
-		}
-		filter["alumni_id"] = uid // hanya milik user
+	sortOrder := 1
+	if order == "desc" {
+		sortOrder = -1
+	}
+	sort := bson.D{{Key: sortBy, Value: sortOrder}}
+	if sortBy != "_id" {
+		sort = append(sort, bson.E{Key: "_id", Value: sortOrder})
 	}
 
-	cursor, err := db.Collection("pekerjaan_alumni").Find(ctx, filter)
+	pipeline := []bson.M{
+		{"$match": withJobScope(individuallyTrashed(bson.M{}), scope)},
+		{"$lookup": bson.M{
+			"from":         "alumni",
+			"localField":   "alumni_id",
+			"foreignField": "_id",
+			"as":           "alumni_info",
+		}},
+		{"$unwind": bson.M{"path": "$alumni_info", "preserveNullAndEmptyArrays": true}},
+		// data lama belum punya deleted_at; updated_at terakhir adalah saat dihapus
+		{"$addFields": bson.M{
+			"nama_alumni": bson.M{"$ifNull": bson.A{"$alumni_info.nama", ""}},
+			"deleted_at":  bson.M{"$ifNull": bson.A{"$deleted_at", "$updated_at"}},
+		}},
+	}
+	if search != "" {
+		regex := primitive.Regex{Pattern: regexp.QuoteMeta(search), Options: "i"}
+		pipeline = append(pipeline, bson.M{"$match": bson.M{"$or": []bson.M{
+			{"nama_perusahaan": regex},
+			{"nama_alumni": regex},
+		}}})
+	}
+	pipeline = append(pipeline, bson.M{"$facet": bson.M{
+		"data": []bson.M{
+			{"$sort": sort},
+			{"$skip": offset},
+			{"$limit": limit},
+			{"$project": bson.M{
+				"alumni_id":       1,
+				"nama_alumni":     1,
+				"nama_perusahaan": 1,
+				"is_deleted":      1,
+				"deleted_at":      1,
+			}},
+		},
+		"total": []bson.M{{"$count": "count"}},
+	}})
+
+	cursor, err := db.Collection("pekerjaan_alumni").Aggregate(ctx, pipeline)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer cursor.Close(ctx)
 
-	var trashList []model.Trash
-	for cursor.Next(ctx) {
-		var p model.PekerjaanAlumni
-		if err := cursor.Decode(&p); err != nil {
-			return nil, err
-		}
+	var result []struct {
+		Data  []model.Trash `bson:"data"`
+		Total []struct {
+			Count int `bson:"count"`
+		} `bson:"total"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
 
-		// ambil nama alumni
-		var alumni model.Alumni
-		if err := db.Collection("alumni").FindOne(ctx, bson.M{"_id": p.AlumniID}).Decode(&alumni); err != nil {
-			alumni.Nama = ""
+	trashList := []model.Trash{}
+	total := 0
+	if len(result) > 0 {
+		if result[0].Data != nil {
+			trashList = result[0].Data
 		}
+		if len(result[0].Total) > 0 {
+			total = result[0].Total[0].Count
+		}
+	}
+	return trashList, total, nil
+}
+
+// Restore mengembalikan pekerjaan dalam scope dari trash. Pekerjaan yang tidak ada di trash
+// atau di luar scope menghasilkan ErrJobNotInTrash, dan pekerjaan yang alumninya masih di
+// trash (atau sudah tidak ada) menghasilkan ErrJobAlumniInTrash.
+func Restore(db *mongo.Database, jobID string, scope model.JobScope) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-		trashList = append(trashList, model.Trash{
-			ID:             p.ID,
-			AlumniID:       p.AlumniID,
-			NamaAlumni:     alumni.Nama,
-			NamaPerusahaan: p.NamaPerusahaan,
-			IsDeleted:      p.IsDeleted,
-		})
+	oid, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return model.NewValidationError("ID pekerjaan tidak valid")
 	}
 
-	return trashList, nil
+	filter := withJobScope(individuallyTrashed(bson.M{"_id": oid}), scope)
+	update := bson.M{
+		"$set":   bson.M{"is_deleted": false, "updated_at": time.Now()},
+		"$unset": bson.M{"deleted_at": ""},
+	}
+	return runInTransaction(ctx, db, func(ctx context.Context) error {
+		var job model.PekerjaanAlumni
+		err := db.Collection("pekerjaan_alumni").FindOne(ctx, filter).Decode(&job)
+		if err == mongo.ErrNoDocuments {
+			return ErrJobNotInTrash
+		}
+		if err != nil {
+			return err
+		}
+
+		active, err := db.Collection("alumni").CountDocuments(ctx, activeAlumni(bson.M{"_id": job.AlumniID}))
+		if err != nil {
+			return err
+		}
+		if active == 0 {
+			return ErrJobAlumniInTrash
+		}
+
+		res, err := db.Collection("pekerjaan_alumni").UpdateOne(ctx, filter, update)
+		if err != nil {
+			return err
+		}
+		if res.MatchedCount == 0 {
+			return ErrJobNotInTrash
+		}
+		return nil
+	})
 }
 
-func Restore(db *mongo.Database, jobID string) (int64, error) {
+// HardDelete menghapus permanen pekerjaan dalam scope yang sudah ada di trash. Pekerjaan yang
+// tidak ada di trash atau di luar scope menghasilkan ErrJobNotInTrash.
+func HardDelete(db *mongo.Database, jobID string, scope model.JobScope) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	oid, err := primitive.ObjectIDFromHex(jobID)
 	if err != nil {
-		return 0, err
+		return model.NewValidationError("ID pekerjaan tidak valid")
 	}
 
-	res, err := db.Collection("pekerjaan_alumni").UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"is_deleted": false}})
+	res, err := db.Collection("pekerjaan_alumni").DeleteOne(ctx, withJobScope(individuallyTrashed(bson.M{"_id": oid}), scope))
 	if err != nil {
-		return 0, err
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrJobNotInTrash
+	}
+	return nil
+}
+
+// deletedBefore mencocokkan pekerjaan yang masuk trash sebelum cutoff. Pekerjaan yang masuk
+// trash sebelum ada deleted_at memakai updated_at, yang diisi saat soft delete.
+func deletedBefore(filter bson.M, cutoff time.Time) bson.M {
+	filter["$or"] = []bson.M{
+		{"deleted_at": bson.M{"$lte": cutoff}},
+		{"deleted_at": bson.M{"$exists": false}, "updated_at": bson.M{"$lte": cutoff}},
 	}
+	return filter
+}
 
-	return res.ModifiedCount, nil
+// expiredTrashFilter mencocokkan pekerjaan di trash yang dihapus sebelum cutoff
+func expiredTrashFilter(cutoff time.Time) bson.M {
+	return individuallyTrashed(deletedBefore(bson.M{}, cutoff))
 }
 
-func HardDelete(db *mongo.Database, jobID string) (int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// FindBulkJobIDs mengambil ID pekerjaan dalam scope yang cocok dengan filter bulk, terurut
+// menurut ID, paling banyak limit. total adalah jumlah seluruh pekerjaan yang cocok.
+func FindBulkJobIDs(db *mongo.Database, f model.BulkJobFilter, scope model.JobScope, limit int) ([]primitive.ObjectID, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	oid, err := primitive.ObjectIDFromHex(jobID)
+	filter := bson.M{"is_deleted": false}
+	if f.Trashed {
+		filter = individuallyTrashed(bson.M{})
+		if f.DeletedBefore != nil {
+			filter = deletedBefore(filter, *f.DeletedBefore)
+		}
+	}
+	// withJobScope menimpa alumni_id, jadi alumni yang diminta diperiksa terhadap scope di sini
+	if f.AlumniID != nil {
+		if !scope.Allows(*f.AlumniID) {
+			return []primitive.ObjectID{}, 0, nil
+		}
+		filter["alumni_id"] = *f.AlumniID
+	} else {
+		filter = withJobScope(filter, scope)
+	}
+
+	total, err := db.Collection("pekerjaan_alumni").CountDocuments(ctx, filter)
 	if err != nil {
-		return 0, err
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.M{"_id": 1}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"_id": 1})
+	cursor, err := db.Collection("pekerjaan_alumni").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	ids := []primitive.ObjectID{}
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, 0, err
+		}
+		ids = append(ids, doc.ID)
+	}
+	return ids, int(total), cursor.Err()
+}
+
+// FindExpiredTrash mengambil pekerjaan di trash yang dihapus sebelum cutoff, paling lama lebih dulu
+func FindExpiredTrash(db *mongo.Database, cutoff time.Time, limit int) ([]model.PekerjaanAlumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "deleted_at", Value: 1}, {Key: "updated_at", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := db.Collection("pekerjaan_alumni").Find(ctx, expiredTrashFilter(cutoff), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []model.PekerjaanAlumni{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
 	}
+	return jobs, nil
+}
+
+// CountExpiredTrash menghitung pekerjaan di trash yang dihapus sebelum cutoff
+func CountExpiredTrash(db *mongo.Database, cutoff time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	res, err := db.Collection("pekerjaan_alumni").DeleteOne(ctx, bson.M{"_id": oid, "is_deleted": true})
+	total, err := db.Collection("pekerjaan_alumni").CountDocuments(ctx, expiredTrashFilter(cutoff))
 	if err != nil {
 		return 0, err
 	}
+	return int(total), nil
+}
+
+// PurgeExpiredJob menghapus permanen satu pekerjaan jika masih memenuhi syarat purge.
+// false berarti pekerjaan sudah direstore atau dihapus sejak diambil.
+func PurgeExpiredJob(db *mongo.Database, id primitive.ObjectID, cutoff time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := expiredTrashFilter(cutoff)
+	filter["_id"] = id
 
-	return res.DeletedCount, nil
+	res, err := db.Collection("pekerjaan_alumni").DeleteOne(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
 }
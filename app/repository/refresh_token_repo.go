@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureTokenIndexes membuat index untuk refresh_tokens dan revoked_tokens.
+// Index TTL membuat MongoDB menghapus token yang sudah kedaluwarsa secara otomatis.
+func EnsureTokenIndexes(db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := db.Collection("refresh_tokens").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		return fmt.Errorf("gagal membuat index refresh_tokens: %v", err)
+	}
+
+	_, err = db.Collection("revoked_tokens").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return fmt.Errorf("gagal membuat index revoked_tokens: %v", err)
+	}
+	return nil
+}
+
+func CreateRefreshToken(db *mongo.Database, rt *model.RefreshToken) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rt.ID = primitive.NewObjectID()
+	rt.CreatedAt = time.Now()
+
+	_, err := db.Collection("refresh_tokens").InsertOne(ctx, rt)
+	return err
+}
+
+// FindRefreshTokenByHash mengambil refresh token berdasarkan hash-nya, nil jika tidak ada
+func FindRefreshTokenByHash(db *mongo.Database, hash string) (*model.RefreshToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var rt model.RefreshToken
+	err := db.Collection("refresh_tokens").FindOne(ctx, bson.M{"token_hash": hash}).Decode(&rt)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rt, nil
+}
+
+// RevokeRefreshToken mencabut satu refresh token. replacedBy diisi saat token dirotasi.
+// Mengembalikan false jika token sudah dicabut sebelumnya.
+func RevokeRefreshToken(db *mongo.Database, id primitive.ObjectID, replacedBy *primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	set := bson.M{"revoked_at": time.Now()}
+	if replacedBy != nil {
+		set["replaced_by"] = *replacedBy
+	}
+
+	res, err := db.Collection("refresh_tokens").UpdateOne(ctx,
+		bson.M{"_id": id, "revoked_at": bson.M{"$exists": false}},
+		bson.M{"$set": set},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// RevokeAccessToken memasukkan JTI access token ke daftar token yang dicabut
+// sampai token tersebut kedaluwarsa.
+func RevokeAccessToken(db *mongo.Database, jti string, userID primitive.ObjectID, expiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if jti == "" || !expiresAt.After(time.Now()) {
+		return nil
+	}
+
+	_, err := db.Collection("revoked_tokens").UpdateOne(ctx,
+		bson.M{"_id": jti},
+		bson.M{"$setOnInsert": bson.M{
+			"user_id":    userID,
+			"expires_at": expiresAt,
+			"revoked_at": time.Now(),
+		}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// RevokeSessionChain mencabut sesi id beserta semua sesi hasil rotasinya (mengikuti
+// replaced_by), termasuk access token yang diterbitkan bersama setiap sesi. Dipakai saat
+// sebuah refresh token terindikasi dipakai ulang sehingga semua token turunannya tidak
+// boleh berlaku lagi.
+func RevokeSessionChain(db *mongo.Database, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	seen := map[primitive.ObjectID]bool{}
+	next := &id
+	for next != nil && !seen[*next] {
+		seen[*next] = true
+
+		var rt model.RefreshToken
+		err := db.Collection("refresh_tokens").FindOne(ctx, bson.M{"_id": *next}).Decode(&rt)
+		if err == mongo.ErrNoDocuments {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := RevokeAccessToken(db, rt.AccessJTI, rt.UserID, rt.AccessExpiresAt); err != nil {
+			return err
+		}
+		_, err = db.Collection("refresh_tokens").UpdateOne(ctx,
+			bson.M{"_id": rt.ID, "revoked_at": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+		)
+		if err != nil {
+			return err
+		}
+		next = rt.ReplacedBy
+	}
+	return nil
+}
+
+// RevokeAllUserTokens mencabut semua sesi aktif milik user beserta access token terakhirnya.
+// Mengembalikan jumlah sesi yang dicabut.
+func RevokeAllUserTokens(db *mongo.Database, userID primitive.ObjectID) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userID, "revoked_at": bson.M{"$exists": false}}
+	cursor, err := db.Collection("refresh_tokens").Find(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []model.RefreshToken
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return 0, err
+	}
+
+	for _, s := range sessions {
+		if err := RevokeAccessToken(db, s.AccessJTI, userID, s.AccessExpiresAt); err != nil {
+			return 0, err
+		}
+	}
+
+	res, err := db.Collection("refresh_tokens").UpdateMany(ctx, filter, bson.M{"$set": bson.M{"revoked_at": time.Now()}})
+	if err != nil {
+		return 0, err
+	}
+	return int(res.ModifiedCount), nil
+}
+
+// IsTokenRevoked memeriksa apakah JTI access token sudah dicabut
+func IsTokenRevoked(db *mongo.Database, jti string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := db.Collection("revoked_tokens").FindOne(ctx, bson.M{"_id": jti}).Err()
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
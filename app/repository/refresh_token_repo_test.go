@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func newMockDB(t *testing.T) *mtest.T {
+	t.Helper()
+	return mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+}
+
+func refreshTokenDoc(id, userID primitive.ObjectID, jti string, replacedBy *primitive.ObjectID) bson.D {
+	doc := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "user_id", Value: userID},
+		{Key: "token_hash", Value: "hash-" + id.Hex()},
+		{Key: "access_jti", Value: jti},
+		{Key: "access_expires_at", Value: time.Now().Add(10 * time.Minute)},
+		{Key: "expires_at", Value: time.Now().Add(24 * time.Hour)},
+	}
+	if replacedBy != nil {
+		doc = append(doc, bson.E{Key: "replaced_by", Value: *replacedBy})
+	}
+	return doc
+}
+
+func modifiedResponse(n int) bson.D {
+	return mtest.CreateSuccessResponse(bson.E{Key: "n", Value: n}, bson.E{Key: "nModified", Value: n})
+}
+
+func TestRevokeRefreshToken(t *testing.T) {
+	mt := newMockDB(t)
+
+	mt.Run("dirotasi", func(mt *mtest.T) {
+		id, next := primitive.NewObjectID(), primitive.NewObjectID()
+		mt.AddMockResponses(modifiedResponse(1))
+
+		ok, err := RevokeRefreshToken(mt.DB, id, &next)
+		if err != nil || !ok {
+			mt.Fatalf("expected token to be revoked, got %v, %v", ok, err)
+		}
+
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		if _, err := update.LookupErr("q", "revoked_at", "$exists"); err != nil {
+			mt.Error("filter harus mengecualikan token yang sudah dicabut")
+		}
+		if got := update.Lookup("u", "$set", "replaced_by").ObjectID(); got != next {
+			mt.Errorf("replaced_by = %s, want %s", got.Hex(), next.Hex())
+		}
+	})
+
+	mt.Run("sudah dicabut", func(mt *mtest.T) {
+		mt.AddMockResponses(modifiedResponse(0))
+
+		ok, err := RevokeRefreshToken(mt.DB, primitive.NewObjectID(), nil)
+		if err != nil || ok {
+			mt.Errorf("expected false for an already revoked token, got %v, %v", ok, err)
+		}
+	})
+}
+
+func TestRevokeSessionChain(t *testing.T) {
+	mt := newMockDB(t)
+
+	mt.Run("mengikuti replaced_by", func(mt *mtest.T) {
+		userID := primitive.NewObjectID()
+		first, second := primitive.NewObjectID(), primitive.NewObjectID()
+		ns := mt.DB.Name() + ".refresh_tokens"
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, refreshTokenDoc(first, userID, "jti-1", &second)),
+			modifiedResponse(1), // revoked_tokens jti-1
+			modifiedResponse(1), // refresh_tokens first
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, refreshTokenDoc(second, userID, "jti-2", nil)),
+			modifiedResponse(1), // revoked_tokens jti-2
+			modifiedResponse(1), // refresh_tokens second
+		)
+
+		if err := RevokeSessionChain(mt.DB, first); err != nil {
+			mt.Fatal(err)
+		}
+
+		var revokedJTIs []string
+		var revokedSessions []primitive.ObjectID
+		for _, e := range mt.GetAllStartedEvents() {
+			if e.CommandName != "update" {
+				continue
+			}
+			q := e.Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("q")
+			switch e.Command.Lookup("update").StringValue() {
+			case "revoked_tokens":
+				revokedJTIs = append(revokedJTIs, q.Document().Lookup("_id").StringValue())
+			case "refresh_tokens":
+				revokedSessions = append(revokedSessions, q.Document().Lookup("_id").ObjectID())
+			}
+		}
+		if len(revokedJTIs) != 2 || revokedJTIs[0] != "jti-1" || revokedJTIs[1] != "jti-2" {
+			mt.Errorf("access token yang dicabut = %v", revokedJTIs)
+		}
+		if len(revokedSessions) != 2 || revokedSessions[0] != first || revokedSessions[1] != second {
+			mt.Errorf("sesi yang dicabut = %v", revokedSessions)
+		}
+	})
+
+	mt.Run("sesi tidak ada", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, mt.DB.Name()+".refresh_tokens", mtest.FirstBatch))
+
+		if err := RevokeSessionChain(mt.DB, primitive.NewObjectID()); err != nil {
+			mt.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestRevokeAllUserTokens(t *testing.T) {
+	mt := newMockDB(t)
+
+	mt.Run("semua sesi aktif", func(mt *mtest.T) {
+		userID := primitive.NewObjectID()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, mt.DB.Name()+".refresh_tokens", mtest.FirstBatch,
+				refreshTokenDoc(primitive.NewObjectID(), userID, "jti-1", nil),
+				refreshTokenDoc(primitive.NewObjectID(), userID, "jti-2", nil),
+			),
+			modifiedResponse(1),
+			modifiedResponse(1),
+			modifiedResponse(2),
+		)
+
+		n, err := RevokeAllUserTokens(mt.DB, userID)
+		if err != nil || n != 2 {
+			mt.Fatalf("expected 2 revoked sessions, got %d, %v", n, err)
+		}
+
+		var revokedJTIs int
+		var filterUser primitive.ObjectID
+		for _, e := range mt.GetAllStartedEvents() {
+			if e.CommandName != "update" {
+				continue
+			}
+			update := e.Command.Lookup("updates").Array().Index(0).Value().Document()
+			if e.Command.Lookup("update").StringValue() == "revoked_tokens" {
+				revokedJTIs++
+				continue
+			}
+			if !update.Lookup("multi").Boolean() {
+				mt.Error("sesi harus dicabut sekaligus dengan update multi")
+			}
+			filterUser = update.Lookup("q", "user_id").ObjectID()
+		}
+		if revokedJTIs != 2 {
+			mt.Errorf("access token yang dicabut = %d, want 2", revokedJTIs)
+		}
+		if filterUser != userID {
+			mt.Errorf("filter user_id = %s, want %s", filterUser.Hex(), userID.Hex())
+		}
+	})
+}
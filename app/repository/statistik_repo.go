@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// salaryBandBoundaries adalah batas rentang gaji (IDR) untuk statistik gaji
+var salaryBandBoundaries = []int64{0, 5000000, 10000000, 15000000, 20000000, 30000000, math.MaxInt64}
+
+// statistikAlumniMatch membangun filter alumni dengan prefix field tertentu
+// ("" untuk koleksi alumni, "alumni_info." untuk hasil $lookup dari pekerjaan).
+func statistikAlumniMatch(f model.StatistikFilter, prefix string) bson.M {
+	match := bson.M{}
+	if f.Jurusan != "" {
+		match[prefix+"jurusan"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(f.Jurusan) + "$", Options: "i"}
+	}
+	if f.TahunLulus > 0 {
+		match[prefix+"tahun_lulus"] = f.TahunLulus
+	}
+	return match
+}
+
+// activeJobsLookup menggabungkan pekerjaan alumni yang belum dihapus ke field "as"
+func activeJobsLookup(as string, extra ...bson.M) bson.M {
+	pipeline := []bson.M{
+		{"$match": bson.M{"$expr": bson.M{"$and": bson.A{
+			bson.M{"$eq": bson.A{"$alumni_id", "$$aid"}},
+			bson.M{"$eq": bson.A{"$is_deleted", false}},
+		}}}},
+	}
+	pipeline = append(pipeline, extra...)
+
+	return bson.M{"$lookup": bson.M{
+		"from":     "pekerjaan_alumni",
+		"let":      bson.M{"aid": "$_id"},
+		"pipeline": pipeline,
+		"as":       as,
+	}}
+}
+
+// GetEmploymentRate menghitung persentase alumni yang sudah bekerja, dikelompokkan per groupBy
+// ("angkatan" atau "jurusan").
+func GetEmploymentRate(db *mongo.Database, f model.StatistikFilter, groupBy string) ([]model.EmploymentRate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if groupBy != "angkatan" && groupBy != "jurusan" {
+		return nil, model.NewValidationError(fmt.Sprintf("pengelompokan %q tidak didukung", groupBy))
+	}
+
+	pipeline := []bson.M{
+		{"$match": activeAlumni(statistikAlumniMatch(f, ""))},
+		activeJobsLookup("pekerjaan", bson.M{"$limit": 1}, bson.M{"$project": bson.M{"_id": 1}}),
+		{"$group": bson.M{
+			"_id":   "$" + groupBy,
+			"total": bson.M{"$sum": 1},
+			"bekerja": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{bson.M{"$size": "$pekerjaan"}, 0}}, 1, 0,
+			}}},
+		}},
+		{"$project": bson.M{
+			"_id":          0,
+			"kelompok":     bson.M{"$toString": "$_id"},
+			"total_alumni": "$total",
+			"bekerja":      1,
+			"persentase": bson.M{"$round": bson.A{
+				bson.M{"$multiply": bson.A{bson.M{"$divide": bson.A{"$bekerja", "$total"}}, 100}}, 2,
+			}},
+		}},
+		{"$sort": bson.M{"kelompok": 1}},
+	}
+
+	cursor, err := db.Collection("alumni").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	results := []model.EmploymentRate{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetMasaTungguBulan mengambil lama waktu (bulan) dari awal tahun lulus sampai tanggal mulai
+// pekerjaan pertama untuk setiap alumni yang sudah bekerja, terurut menaik.
+// Pekerjaan yang dimulai sebelum lulus dihitung 0 bulan.
+func GetMasaTungguBulan(db *mongo.Database, f model.StatistikFilter) ([]int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	match := activeAlumni(statistikAlumniMatch(f, ""))
+	if _, ok := match["tahun_lulus"]; !ok {
+		match["tahun_lulus"] = bson.M{"$gt": 0}
+	}
+
+	pipeline := []bson.M{
+		{"$match": match},
+		activeJobsLookup("pekerjaan_pertama",
+			bson.M{"$sort": bson.M{"tanggal_mulai_kerja": 1}},
+			bson.M{"$limit": 1},
+		),
+		{"$unwind": "$pekerjaan_pertama"},
+		{"$project": bson.M{
+			"_id": 0,
+			"bulan": bson.M{"$max": bson.A{0, bson.M{"$add": bson.A{
+				bson.M{"$multiply": bson.A{
+					bson.M{"$subtract": bson.A{bson.M{"$year": "$pekerjaan_pertama.tanggal_mulai_kerja"}, "$tahun_lulus"}},
+					12,
+				}},
+				bson.M{"$subtract": bson.A{bson.M{"$month": "$pekerjaan_pertama.tanggal_mulai_kerja"}, 1}},
+			}}}},
+		}},
+		{"$sort": bson.M{"bulan": 1}},
+	}
+
+	cursor, err := db.Collection("alumni").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	months := []int{}
+	for cursor.Next(ctx) {
+		var doc struct {
+			Bulan int `bson:"bulan"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		months = append(months, doc.Bulan)
+	}
+	return months, cursor.Err()
+}
+
+// jobStatistikStages mengambil pekerjaan aktif yang alumninya cocok dengan filter
+func jobStatistikStages(f model.StatistikFilter) []bson.M {
+	stages := []bson.M{{"$match": bson.M{"is_deleted": false}}}
+
+	alumniMatch := statistikAlumniMatch(f, "alumni_info.")
+	if len(alumniMatch) > 0 {
+		stages = append(stages,
+			bson.M{"$lookup": bson.M{
+				"from":         "alumni",
+				"localField":   "alumni_id",
+				"foreignField": "_id",
+				"as":           "alumni_info",
+			}},
+			bson.M{"$unwind": "$alumni_info"},
+			bson.M{"$match": alumniMatch},
+		)
+	}
+	return stages
+}
+
+// GetJobDistribution menghitung jumlah pekerjaan per nilai field ("bidang_industri" atau "lokasi_kerja")
+func GetJobDistribution(db *mongo.Database, f model.StatistikFilter, field string) ([]model.DistribusiItem, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if field != "bidang_industri" && field != "lokasi_kerja" {
+		return nil, model.NewValidationError(fmt.Sprintf("distribusi %q tidak didukung", field))
+	}
+
+	pipeline := append(jobStatistikStages(f),
+		bson.M{"$group": bson.M{
+			"_id":    bson.M{"$ifNull": bson.A{"$" + field, ""}},
+			"jumlah": bson.M{"$sum": 1},
+		}},
+		bson.M{"$project": bson.M{"_id": 0, "nilai": "$_id", "jumlah": 1}},
+		bson.M{"$sort": bson.D{{Key: "jumlah", Value: -1}, {Key: "nilai", Value: 1}}},
+	)
+
+	cursor, err := db.Collection("pekerjaan_alumni").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	results := []model.DistribusiItem{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetSalaryBands mengelompokkan pekerjaan (IDR) ke dalam rentang gaji berdasarkan gaji minimum
+func GetSalaryBands(db *mongo.Database, f model.StatistikFilter) ([]model.SalaryBand, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	boundaries := bson.A{}
+	for _, b := range salaryBandBoundaries {
+		boundaries = append(boundaries, b)
+	}
+
+	pipeline := append(jobStatistikStages(f),
+		bson.M{"$match": bson.M{
+			"gaji_range.min":       bson.M{"$type": "number"},
+			"gaji_range.mata_uang": "IDR",
+		}},
+		bson.M{"$bucket": bson.M{
+			"groupBy":    "$gaji_range.min",
+			"boundaries": boundaries,
+			"output":     bson.M{"jumlah": bson.M{"$sum": 1}},
+		}},
+	)
+
+	cursor, err := db.Collection("pekerjaan_alumni").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	counts := map[int64]int{}
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID     int64 `bson:"_id"`
+			Jumlah int   `bson:"jumlah"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		counts[doc.ID] = doc.Jumlah
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	// semua rentang tetap ditampilkan walaupun jumlahnya 0
+	bands := make([]model.SalaryBand, 0, len(salaryBandBoundaries)-1)
+	for i := 0; i < len(salaryBandBoundaries)-1; i++ {
+		dari, sampai := salaryBandBoundaries[i], salaryBandBoundaries[i+1]
+		label := fmt.Sprintf("%d - %d juta", dari/1000000, sampai/1000000)
+		if sampai == math.MaxInt64 {
+			label = fmt.Sprintf(">= %d juta", dari/1000000)
+		}
+		bands = append(bands, model.SalaryBand{Label: label, Dari: dari, Sampai: sampai, Jumlah: counts[dari]})
+	}
+	return bands, nil
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// runInTransaction menjalankan fn dalam satu transaksi jika MongoDB mendukungnya. Pada
+// MongoDB standalone fn dijalankan langsung, sehingga fn harus mengurutkan operasinya agar
+// kegagalan di tengah jalan tetap aman untuk diulang.
+func runInTransaction(ctx context.Context, db *mongo.Database, fn func(ctx context.Context) error) error {
+	if !SupportsTransactions(db) {
+		return fn(ctx)
+	}
+
+	session, err := db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
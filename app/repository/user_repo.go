@@ -3,53 +3,194 @@ package repository
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/noorfarihaf11/clean-arc/app/model"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func RegisterUser(db *mongo.Database, user *model.User) (*model.User, error) {
+// userCollation membuat perbandingan username dan email tidak membedakan huruf besar/kecil
+var userCollation = &options.Collation{Locale: "en", Strength: 2}
+
+// EnsureUserIndexes membuat unique index untuk username dan email.
+// Gagal jika data lama masih memiliki duplikat; bereskan duplikat tersebut terlebih dahulu.
+func EnsureUserIndexes(db *mongo.Database) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	user.ID = primitive.NewObjectID()
-	user.CreatedAt = time.Now()
+	_, err := db.Collection("users").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "username", Value: 1}},
+			Options: options.Index().SetName("username_unique").SetUnique(true).SetCollation(userCollation),
+		},
+		{
+			Keys:    bson.D{{Key: "email", Value: 1}},
+			Options: options.Index().SetName("email_unique").SetUnique(true).SetCollation(userCollation),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("gagal membuat index users: %v", err)
+	}
+	return nil
+}
 
-	// ✅ Simpan user baru ke collection users
-	_, err := db.Collection("users").InsertOne(ctx, user)
+// FindUserConflicts memeriksa apakah username atau email sudah dipakai user lain
+func FindUserConflicts(db *mongo.Database, username, email string) (usernameTaken, emailTaken bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"$or": []bson.M{{"username": username}, {"email": email}}}
+	cursor, err := db.Collection("users").Find(ctx, filter,
+		options.Find().SetCollation(userCollation).SetProjection(bson.M{"username": 1, "email": 1}))
 	if err != nil {
-		return nil, fmt.Errorf("gagal menambahkan user: %v", err)
+		return false, false, err
 	}
+	defer cursor.Close(ctx)
 
-	// 🧠 Debug untuk memastikan role terbaca
-	fmt.Println("DEBUG ROLE:", user.Role)
+	var users []model.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return false, false, err
+	}
+	for _, u := range users {
+		if strings.EqualFold(u.Username, username) {
+			usernameTaken = true
+		}
+		if strings.EqualFold(u.Email, email) {
+			emailTaken = true
+		}
+	}
+	return usernameTaken, emailTaken, nil
+}
 
-	role := strings.TrimSpace(strings.ToLower(user.Role))
-	switch role {
-	case "alumni":
-		// ✅ Jika user role = alumni, otomatis buat data di koleksi alumni
-		alumni := model.Alumni{
-			ID:        primitive.NewObjectID(),
-			UserID:    &user.ID, // pakai pointer, karena field opsional
-			Nama:      user.Username,
-			Email:     user.Email,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+// RegisterUser menyimpan user baru beserta profil alumninya (jika ada) sebagai satu kesatuan.
+func RegisterUser(db *mongo.Database, user *model.User, alumni *model.Alumni) (*model.User, error) {
+	now := time.Now()
+	if alumni != nil {
+		alumni.ID = primitive.NewObjectID()
+		alumni.CreatedAt = now
+		alumni.UpdatedAt = now
+	}
+
+	err := runUserRegistration(db, user, func(ctx context.Context) error {
+		if alumni == nil {
+			return nil
+		}
+		alumni.UserID = &user.ID
+		if _, err := db.Collection("alumni").InsertOne(ctx, alumni); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return alumniWriteError(err)
+			}
+			return fmt.Errorf("gagal menambahkan data alumni: %w", err)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
 
-		_, err = db.Collection("alumni").InsertOne(ctx, alumni)
+// RegisterUserClaimingAlumni menyimpan user baru lalu menautkannya ke data alumni yang sudah ada.
+// Kode klaim (jika dipakai) ditandai terpakai dalam unit yang sama.
+func RegisterUserClaimingAlumni(db *mongo.Database, user *model.User, alumniID primitive.ObjectID, claimCodeID *primitive.ObjectID) (*model.User, error) {
+	err := runUserRegistration(db, user, func(ctx context.Context) error {
+		res, err := db.Collection("alumni").UpdateOne(ctx,
+			bson.M{"_id": alumniID, "user_id": nil},
+			bson.M{"$set": bson.M{"user_id": user.ID, "updated_at": time.Now()}},
+		)
 		if err != nil {
-			return nil, fmt.Errorf("gagal menambahkan data alumni: %v", err)
+			return fmt.Errorf("gagal menautkan data alumni: %w", err)
+		}
+		if res.MatchedCount == 0 {
+			return ErrAlumniAlreadyClaimed
+		}
+		if claimCodeID != nil {
+			return markClaimCodeUsed(ctx, db, *claimCodeID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// runUserRegistration menyimpan user lalu menjalankan withProfile. Pada replica set / sharded
+// cluster keduanya berjalan dalam satu transaksi. Pada MongoDB standalone yang tidak mendukung
+// transaksi, user yang sudah tersimpan dihapus kembali jika withProfile gagal.
+func runUserRegistration(db *mongo.Database, user *model.User, withProfile func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	user.ID = primitive.NewObjectID()
+	user.CreatedAt = time.Now()
+
+	if !SupportsTransactions(db) {
+		return registerUserWithCompensation(ctx, db, user, withProfile)
+	}
+
+	session, err := db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		if _, err := db.Collection("users").InsertOne(sc, user); err != nil {
+			return nil, fmt.Errorf("gagal menambahkan user: %w", err)
 		}
+		return nil, withProfile(sc)
+	})
+	return err
+}
 
-		fmt.Println("✅ Data alumni berhasil dibuat otomatis untuk user:", user.Username)
+func registerUserWithCompensation(ctx context.Context, db *mongo.Database, user *model.User, withProfile func(ctx context.Context) error) error {
+	if _, err := db.Collection("users").InsertOne(ctx, user); err != nil {
+		return fmt.Errorf("gagal menambahkan user: %w", err)
+	}
 
-	default:
-		fmt.Println("➡️ Role bukan alumni, tidak dimasukkan ke koleksi alumni.")
+	if err := withProfile(ctx); err != nil {
+		// pakai context baru agar rollback tetap jalan walau ctx sudah habis
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, delErr := db.Collection("users").DeleteOne(cleanupCtx, bson.M{"_id": user.ID}); delErr != nil {
+			log.Printf("Gagal menghapus user %s setelah profil alumni gagal disimpan: %v", user.ID.Hex(), delErr)
+		}
+		return err
 	}
+	return nil
+}
 
-	return user, nil
+var (
+	txSupportOnce sync.Once
+	txSupported   bool
+)
+
+// SupportsTransactions memeriksa sekali apakah server adalah anggota replica set atau mongos,
+// syarat MongoDB untuk multi-document transaction
+func SupportsTransactions(db *mongo.Database) bool {
+	txSupportOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var hello struct {
+			SetName string `bson:"setName"`
+			Msg     string `bson:"msg"`
+		}
+		if err := db.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+			log.Printf("Gagal memeriksa topologi MongoDB, transaksi tidak dipakai: %v", err)
+			return
+		}
+		txSupported = hello.SetName != "" || hello.Msg == "isdbgrid"
+		if !txSupported {
+			log.Println("MongoDB standalone terdeteksi, registrasi memakai rollback manual tanpa transaksi")
+		}
+	})
+	return txSupported
 }
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"crypto/rand"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"github.com/noorfarihaf11/clean-arc/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// claimCodeAlphabet tanpa karakter yang mudah tertukar (0/O, 1/I/L)
+const claimCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+const (
+	claimCodeLength = 8
+	claimCodeTTL    = 7 * 24 * time.Hour
+)
+
+// nimClaim adalah hasil pemeriksaan NIM saat registrasi. existing nil berarti profil alumni
+// baru dibuat; rejection terisi berarti registrasi ditolak dengan jenis error kind.
+type nimClaim struct {
+	existing  *model.Alumni
+	codeID    *primitive.ObjectID
+	needsCode bool
+	review    string
+	rejection string
+	kind      model.ErrorKind
+}
+
+// evaluateNIMClaim menentukan nasib registrasi berdasarkan data alumni dengan NIM yang sama:
+// tidak ada -> profil baru; satu dan belum tertaut -> diklaim jika email cocok atau kode klaim
+// dikirim; tertaut ke akun lain atau data ganda -> ditolak dan diteruskan ke admin.
+func evaluateNIMClaim(req model.RegisterRequest, matches []model.Alumni) nimClaim {
+	switch {
+	case len(matches) == 0:
+		return nimClaim{}
+	case len(matches) > 1:
+		return nimClaim{
+			review:    model.ClaimReasonDuplikat,
+			rejection: "NIM memiliki lebih dari satu data alumni dan sedang diperiksa admin",
+			kind:      model.ErrKindConflict,
+		}
+	}
+
+	a := matches[0]
+	if a.UserID != nil {
+		return nimClaim{
+			review:    model.ClaimReasonSudahDiklaim,
+			rejection: "NIM sudah terhubung dengan akun lain, laporan diteruskan ke admin",
+			kind:      model.ErrKindConflict,
+		}
+	}
+
+	if a.Email != "" && strings.EqualFold(a.Email, req.Email) {
+		return nimClaim{existing: &a}
+	}
+	if normalizeClaimCode(req.ClaimCode) != "" {
+		return nimClaim{existing: &a, needsCode: true}
+	}
+	return nimClaim{
+		rejection: "NIM sudah terdaftar. Gunakan email yang tercatat di data alumni atau minta kode klaim ke admin",
+		kind:      model.ErrKindForbidden,
+	}
+}
+
+// planNIMClaim memeriksa NIM registrasi terhadap data alumni, mencatat klaim bermasalah
+// untuk admin, dan memverifikasi kode klaim bila diperlukan
+func planNIMClaim(db *mongo.Database, req model.RegisterRequest) (nimClaim, error) {
+	matches, err := repository.FindAlumniByNIM(db, req.NIM)
+	if err != nil {
+		return nimClaim{}, err
+	}
+
+	claim := evaluateNIMClaim(req, matches)
+
+	if claim.review != "" {
+		review := &model.AlumniClaimReview{
+			NIM:      req.NIM,
+			Reason:   claim.review,
+			Username: req.Username,
+			Email:    req.Email,
+		}
+		for _, a := range matches {
+			review.AlumniIDs = append(review.AlumniIDs, a.ID)
+		}
+		// pesan penolakan menyebut klaim diteruskan ke admin, jadi gagal mencatat harus gagal juga
+		created, err := repository.CreateClaimReview(db, review)
+		if err != nil {
+			return nimClaim{}, err
+		}
+		if !created {
+			log.Printf("Klaim NIM %s oleh %s tidak dicatat, NIM masih menunggu pemeriksaan admin", req.NIM, req.Username)
+		}
+	}
+
+	if claim.needsCode {
+		codeID, err := repository.VerifyClaimCode(db, claim.existing.ID, utils.HashToken(normalizeClaimCode(req.ClaimCode)))
+		if err != nil {
+			return nimClaim{}, err
+		}
+		if codeID == nil {
+			return nimClaim{
+				rejection: "Kode klaim salah atau sudah tidak berlaku",
+				kind:      model.ErrKindForbidden,
+			}, nil
+		}
+		claim.codeID = codeID
+	}
+
+	return claim, nil
+}
+
+// normalizeClaimCode membuang tanda hubung dan spasi lalu mengubah ke huruf besar
+func normalizeClaimCode(code string) string {
+	code = strings.ToUpper(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// generateClaimCode membuat kode klaim acak berformat XXXX-XXXX
+func generateClaimCode() (string, error) {
+	max := big.NewInt(int64(len(claimCodeAlphabet)))
+	b := make([]byte, claimCodeLength)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = claimCodeAlphabet[n.Int64()]
+	}
+	return string(b[:4]) + "-" + string(b[4:]), nil
+}
+
+// IssueClaimCodeService godoc
+// @Summary Menerbitkan kode klaim alumni
+// @Description Admin menerbitkan kode klaim sekali pakai (berlaku 7 hari) untuk data alumni yang belum tertaut ke akun. Alumni memakai kode ini sebagai claim_code saat registrasi jika emailnya tidak sama dengan email di data alumni. Kode lama yang belum terpakai otomatis hangus. Kode hanya ditampilkan sekali.
+// @Tags Alumni
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Alumni"
+// @Success 201 {object} model.ClaimCodeResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/alumni/{id}/claim-code [post]
+func IssueClaimCodeService(c *fiber.Ctx, db *mongo.Database) error {
+	id := c.Params("id")
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return model.NewValidationError("ID tidak valid")
+	}
+
+	alumni, err := repository.GetAlumniByID(db, id)
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil data alumni")
+	}
+	if alumni.UserID != nil {
+		return model.NewConflictError("Data alumni sudah tertaut ke akun")
+	}
+
+	code, err := generateClaimCode()
+	if err != nil {
+		return model.WrapError(err, "Gagal membuat kode klaim")
+	}
+
+	username, _ := c.Locals("username").(string)
+	claimCode := &model.AlumniClaimCode{
+		AlumniID:  alumni.ID,
+		CodeHash:  utils.HashToken(normalizeClaimCode(code)),
+		CreatedBy: username,
+		ExpiresAt: time.Now().Add(claimCodeTTL),
+	}
+	if err := repository.CreateClaimCode(db, claimCode); err != nil {
+		return model.WrapError(err, "Gagal menyimpan kode klaim")
+	}
+
+	log.Printf("Admin %s menerbitkan kode klaim untuk alumni %s", username, alumni.NIM)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": "Kode klaim berhasil dibuat",
+		"data": model.ClaimCodeResponse{
+			AlumniID:  alumni.ID,
+			NIM:       alumni.NIM,
+			Code:      code,
+			ExpiresAt: claimCode.ExpiresAt,
+		},
+	})
+}
+
+var claimReviewSortFields = map[string]string{"created_at": "created_at"}
+
+// GetClaimReviewsService godoc
+// @Summary Daftar klaim NIM yang perlu diperiksa
+// @Description Registrasi yang ditolak karena NIM sudah tertaut ke akun lain atau memiliki data alumni ganda, terbaru lebih dulu
+// @Tags Alumni
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status klaim" Enums(pending, resolved, rejected) default(pending)
+// @Param page query int false "Halaman" default(1)
+// @Param limit query int false "Jumlah data per halaman (maks 100)" default(10)
+// @Success 200 {object} model.ClaimReviewResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/alumni/claim-reviews [get]
+func GetClaimReviewsService(c *fiber.Ctx, db *mongo.Database) error {
+	meta, _ := parseListQuery(c, claimReviewSortFields, "created_at")
+	meta.Order = "desc"
+	status := c.Query("status", model.ClaimReviewPending)
+
+	reviews, err := repository.GetClaimReviews(db, status, meta.Limit, pageOffset(meta))
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil klaim NIM")
+	}
+	total, err := repository.CountClaimReviews(db, status)
+	if err != nil {
+		return model.WrapError(err, "Gagal menghitung klaim NIM")
+	}
+
+	return c.JSON(model.ClaimReviewResponse{
+		Message: "Berhasil mengambil klaim NIM",
+		Success: true,
+		Data:    reviews,
+		Meta:    withTotal(meta, total),
+	})
+}
+
+// ResolveClaimReviewService godoc
+// @Summary Memutuskan klaim NIM
+// @Description Admin menandai klaim NIM sebagai resolved (data sudah dibereskan, misalnya duplikat digabung) atau rejected (klaim tidak sah)
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID klaim"
+// @Param request body model.ResolveClaimReviewRequest true "Keputusan admin"
+// @Success 200 {object} model.AlumniClaimReview
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/alumni/claim-reviews/{id} [put]
+func ResolveClaimReviewService(c *fiber.Ctx, db *mongo.Database) error {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return model.NewValidationError("ID tidak valid")
+	}
+
+	var req model.ResolveClaimReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return model.NewValidationError("Request body tidak valid: " + err.Error())
+	}
+	if req.Status != model.ClaimReviewResolved && req.Status != model.ClaimReviewRejected {
+		return model.NewValidationError("status harus resolved atau rejected")
+	}
+
+	username, _ := c.Locals("username").(string)
+	review, err := repository.ResolveClaimReview(db, id, req.Status, strings.TrimSpace(req.Catatan), username)
+	if err != nil {
+		return model.WrapError(err, "Gagal menyimpan keputusan klaim")
+	}
+	if review == nil {
+		return model.NewNotFoundError("Klaim tidak ditemukan atau sudah diputuskan")
+	}
+
+	before := *review
+	before.Status, before.Catatan, before.ResolvedBy, before.ResolvedAt = model.ClaimReviewPending, "", "", nil
+	recordAudit(c, db, model.AuditUpdate, model.AuditEntityClaimReview, id.Hex(), before, review)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Klaim NIM berhasil diputuskan",
+		"data":    review,
+	})
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestEvaluateNIMClaim(t *testing.T) {
+	owner := primitive.NewObjectID()
+	unclaimed := model.Alumni{ID: primitive.NewObjectID(), NIM: "187221001", Email: "budi@mail.com"}
+	claimed := model.Alumni{ID: primitive.NewObjectID(), NIM: "187221001", UserID: &owner}
+
+	req := model.RegisterRequest{NIM: "187221001", Email: "BUDI@mail.com"}
+
+	tests := []struct {
+		name      string
+		req       model.RegisterRequest
+		matches   []model.Alumni
+		linked    bool
+		needsCode bool
+		kind      model.ErrorKind
+		review    string
+	}{
+		{name: "nim baru", req: req},
+		{name: "email cocok", req: req, matches: []model.Alumni{unclaimed}, linked: true},
+		{
+			name:    "email beda tanpa kode",
+			req:     model.RegisterRequest{NIM: "187221001", Email: "lain@mail.com"},
+			matches: []model.Alumni{unclaimed},
+			kind:    model.ErrKindForbidden,
+		},
+		{
+			name:      "email beda dengan kode",
+			req:       model.RegisterRequest{NIM: "187221001", Email: "lain@mail.com", ClaimCode: "k7q2-m9xd"},
+			matches:   []model.Alumni{unclaimed},
+			linked:    true,
+			needsCode: true,
+		},
+		{name: "sudah diklaim", req: req, matches: []model.Alumni{claimed}, kind: model.ErrKindConflict, review: model.ClaimReasonSudahDiklaim},
+		{name: "data ganda", req: req, matches: []model.Alumni{unclaimed, unclaimed}, kind: model.ErrKindConflict, review: model.ClaimReasonDuplikat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := evaluateNIMClaim(tt.req, tt.matches)
+			if (claim.existing != nil) != tt.linked || claim.needsCode != tt.needsCode ||
+				claim.kind != tt.kind || claim.review != tt.review {
+				t.Errorf("unexpected claim: %+v", claim)
+			}
+			if tt.kind != "" && claim.rejection == "" {
+				t.Error("expected rejection message")
+			}
+		})
+	}
+}
+
+func TestGenerateClaimCode(t *testing.T) {
+	code, err := generateClaimCode()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !regexp.MustCompile(`^[A-Z2-9]{4}-[A-Z2-9]{4}$`).MatchString(code) {
+		t.Errorf("unexpected code format: %s", code)
+	}
+	if normalizeClaimCode(" "+code[:4]+" "+code[5:]) != normalizeClaimCode(code) {
+		t.Error("normalization should ignore spaces and dashes")
+	}
+}
+
+func TestPlanNIMClaim_ReviewNotRecorded(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("gagal mencatat klaim", func(mt *mtest.T) {
+		owner := primitive.NewObjectID()
+		mt.AddMockResponses(
+			cursor(mt, "alumni", bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "nim", Value: "187221001"}, {Key: "user_id", Value: owner}}),
+			mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 2, Message: "bad value"}),
+		)
+
+		if _, err := planNIMClaim(mt.DB, model.RegisterRequest{NIM: "187221001", Username: "budi"}); err == nil {
+			mt.Error("klaim yang gagal dicatat tidak boleh dilaporkan sebagai diteruskan ke admin")
+		}
+	})
+
+	mt.Run("klaim pending sudah ada", func(mt *mtest.T) {
+		owner := primitive.NewObjectID()
+		mt.AddMockResponses(
+			cursor(mt, "alumni", bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "nim", Value: "187221001"}, {Key: "user_id", Value: owner}}),
+			mtest.CreateWriteErrorsResponse(mtest.WriteError{Code: 11000, Message: "duplicate key"}),
+		)
+
+		claim, err := planNIMClaim(mt.DB, model.RegisterRequest{NIM: "187221001", Username: "budi"})
+		if err != nil || claim.kind != model.ErrKindConflict {
+			mt.Errorf("expected conflict rejection, got %+v, %v", claim, err)
+		}
+	})
+}
+
+func TestCheckOwnAlumniCreate(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	userID := primitive.NewObjectID()
+
+	mt.Run("akun sudah punya profil", func(mt *mtest.T) {
+		mt.AddMockResponses(cursor(mt, "alumni", bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "user_id", Value: userID}}))
+
+		if err := checkOwnAlumniCreate(mt.DB, userID, "187221001"); !errors.Is(err, repository.ErrAlumniProfileExists) {
+			mt.Errorf("expected ErrAlumniProfileExists, got %v", err)
+		}
+	})
+
+	mt.Run("nim sudah terdaftar", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			cursor(mt, "alumni"),
+			cursor(mt, "alumni", bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "nim", Value: "187221001"}}),
+		)
+
+		if err := checkOwnAlumniCreate(mt.DB, userID, "187221001"); model.ErrorKindOf(err) != model.ErrKindConflict {
+			mt.Errorf("expected conflict, got %v", err)
+		}
+	})
+
+	mt.Run("profil baru", func(mt *mtest.T) {
+		mt.AddMockResponses(cursor(mt, "alumni"), cursor(mt, "alumni"))
+
+		if err := checkOwnAlumniCreate(mt.DB, userID, "187221001"); err != nil {
+			mt.Errorf("unexpected error: %v", err)
+		}
+	})
+}
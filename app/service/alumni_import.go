@@ -0,0 +1,334 @@
+package service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/mail"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"github.com/xuri/excelize/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// nimPattern adalah format NIM yang diterima: 6-15 digit angka
+var nimPattern = regexp.MustCompile(`^[0-9]{6,15}$`)
+
+// alumniImportColumns adalah kolom yang dikenali pada baris header file import
+var alumniImportColumns = []string{"nim", "nama", "jurusan", "angkatan", "tahun_lulus", "email", "no_telepon", "alamat"}
+
+// ImportRow -> satu baris data mentah dari file import
+type ImportRow struct {
+	Baris  int
+	Values map[string]string
+}
+
+// ReadAlumniCSV membaca file CSV berheader menjadi daftar ImportRow
+func ReadAlumniCSV(r io.Reader) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("file CSV tidak valid: %v", err)
+	}
+	return recordsToImportRows(records)
+}
+
+// ReadAlumniXLSX membaca sheet pertama file XLSX berheader menjadi daftar ImportRow
+func ReadAlumniXLSX(r io.Reader) ([]ImportRow, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("file XLSX tidak valid: %v", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("file XLSX tidak memiliki sheet")
+	}
+
+	records, err := f.GetRows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca sheet %s: %v", sheets[0], err)
+	}
+	return recordsToImportRows(records)
+}
+
+func recordsToImportRows(records [][]string) ([]ImportRow, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("file kosong")
+	}
+
+	header := make(map[int]string)
+	for i, col := range records[0] {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(col, "\ufeff")))
+		name = strings.ReplaceAll(name, " ", "_")
+		for _, known := range alumniImportColumns {
+			if name == known {
+				header[i] = name
+			}
+		}
+	}
+	if _, ok := findColumn(header, "nim"); !ok {
+		return nil, fmt.Errorf("kolom nim wajib ada di header")
+	}
+
+	rows := []ImportRow{}
+	for i, record := range records[1:] {
+		values := make(map[string]string)
+		empty := true
+		for idx, name := range header {
+			if idx < len(record) {
+				values[name] = strings.TrimSpace(record[idx])
+				if values[name] != "" {
+					empty = false
+				}
+			}
+		}
+		if empty {
+			continue
+		}
+		// baris 1 adalah header
+		rows = append(rows, ImportRow{Baris: i + 2, Values: values})
+	}
+	return rows, nil
+}
+
+func findColumn(header map[int]string, name string) (int, bool) {
+	for idx, col := range header {
+		if col == name {
+			return idx, true
+		}
+	}
+	return 0, false
+}
+
+// ValidateAlumniImportRow mengubah satu ImportRow menjadi model.Alumni beserta daftar kesalahannya
+func ValidateAlumniImportRow(row ImportRow) (model.Alumni, []string) {
+	var errs []string
+	v := row.Values
+
+	a := model.Alumni{
+		NIM:     v["nim"],
+		Nama:    v["nama"],
+		Jurusan: v["jurusan"],
+		Email:   v["email"],
+	}
+	if s := v["no_telepon"]; s != "" {
+		a.NoTelp = &s
+	}
+	if s := v["alamat"]; s != "" {
+		a.Alamat = &s
+	}
+
+	if !nimPattern.MatchString(a.NIM) {
+		errs = append(errs, "nim harus berupa 6-15 digit angka")
+	}
+	if a.Nama == "" {
+		errs = append(errs, "nama wajib diisi")
+	}
+	if a.Jurusan == "" {
+		errs = append(errs, "jurusan wajib diisi")
+	}
+	if a.Email != "" {
+		if addr, err := mail.ParseAddress(a.Email); err != nil || addr.Address != a.Email {
+			errs = append(errs, "email tidak valid")
+		}
+	}
+
+	var err error
+	if a.Angkatan, err = strconv.Atoi(v["angkatan"]); err != nil || a.Angkatan <= 0 {
+		errs = append(errs, "angkatan harus berupa tahun")
+	}
+	if v["tahun_lulus"] != "" {
+		if a.TahunLulus, err = strconv.Atoi(v["tahun_lulus"]); err != nil || a.TahunLulus <= 0 {
+			errs = append(errs, "tahun_lulus harus berupa tahun")
+		} else if a.Angkatan > 0 && a.Angkatan > a.TahunLulus {
+			errs = append(errs, "angkatan tidak boleh lebih besar dari tahun_lulus")
+		}
+	}
+
+	return a, errs
+}
+
+// alumniImportChanges mengembalikan nama field yang akan berubah jika existing ditimpa dengan incoming
+func alumniImportChanges(existing, incoming model.Alumni) []string {
+	var changes []string
+	if existing.Nama != incoming.Nama {
+		changes = append(changes, "nama")
+	}
+	if existing.Jurusan != incoming.Jurusan {
+		changes = append(changes, "jurusan")
+	}
+	if existing.Angkatan != incoming.Angkatan {
+		changes = append(changes, "angkatan")
+	}
+	if existing.TahunLulus != incoming.TahunLulus {
+		changes = append(changes, "tahun_lulus")
+	}
+	if existing.Email != incoming.Email {
+		changes = append(changes, "email")
+	}
+	if stringValue(existing.NoTelp) != stringValue(incoming.NoTelp) {
+		changes = append(changes, "no_telepon")
+	}
+	if stringValue(existing.Alamat) != stringValue(incoming.Alamat) {
+		changes = append(changes, "alamat")
+	}
+	return changes
+}
+
+// keepStoredOptionalFields mengisi kolom opsional yang kosong pada incoming dengan nilai yang
+// sudah tersimpan, karena kolom kosong tidak menimpa data saat disimpan
+func keepStoredOptionalFields(existing, incoming model.Alumni) model.Alumni {
+	if incoming.TahunLulus == 0 {
+		incoming.TahunLulus = existing.TahunLulus
+	}
+	if incoming.Email == "" {
+		incoming.Email = existing.Email
+	}
+	if incoming.NoTelp == nil {
+		incoming.NoTelp = existing.NoTelp
+	}
+	if incoming.Alamat == nil {
+		incoming.Alamat = existing.Alamat
+	}
+	return incoming
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// PlanAlumniImport memvalidasi semua baris dan menentukan aksi per baris berdasarkan data yang sudah ada.
+// Mengembalikan hasil per baris dan daftar alumni yang perlu ditulis.
+func PlanAlumniImport(rows []ImportRow, existing map[string]model.Alumni) (model.ImportResult, []model.Alumni) {
+	result := model.ImportResult{TotalBaris: len(rows), Baris: []model.ImportRowResult{}}
+	toWrite := []model.Alumni{}
+	seen := make(map[string]int)
+
+	for _, row := range rows {
+		alumni, errs := ValidateAlumniImportRow(row)
+		if first, dup := seen[alumni.NIM]; dup && alumni.NIM != "" {
+			errs = append(errs, fmt.Sprintf("nim duplikat dengan baris %d", first))
+		}
+		rr := model.ImportRowResult{Baris: row.Baris, NIM: alumni.NIM}
+
+		if len(errs) > 0 {
+			rr.Aksi, rr.Errors = "ditolak", errs
+			result.Ditolak++
+			result.Baris = append(result.Baris, rr)
+			continue
+		}
+		seen[alumni.NIM] = row.Baris
+
+		if old, ok := existing[alumni.NIM]; ok {
+			alumni = keepStoredOptionalFields(old, alumni)
+			rr.Perubahan = alumniImportChanges(old, alumni)
+			if len(rr.Perubahan) == 0 {
+				rr.Aksi = "tidak_berubah"
+				result.TidakBerubah++
+			} else {
+				rr.Aksi = "diperbarui"
+				result.Diperbarui++
+				toWrite = append(toWrite, alumni)
+			}
+		} else {
+			rr.Aksi = "dibuat"
+			result.Dibuat++
+			toWrite = append(toWrite, alumni)
+		}
+		result.Baris = append(result.Baris, rr)
+	}
+
+	return result, toWrite
+}
+
+// ImportAlumniService godoc
+// @Summary Import data alumni dari CSV/XLSX
+// @Description Mengunggah file CSV atau XLSX berheader (nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat). Setiap baris divalidasi lalu disimpan berdasarkan NIM (dibuat baru atau diperbarui). Kolom opsional (tahun_lulus, email, no_telepon, alamat) yang tidak ada di file atau kosong tidak menimpa data yang sudah tersimpan. Baris yang tidak valid dilaporkan dan dilewati. Gunakan dry_run=true untuk melihat perubahan tanpa menyimpan.
+// @Tags Alumni
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "File CSV atau XLSX"
+// @Param dry_run query bool false "Hanya simulasi, tidak menyimpan data"
+// @Success 200 {object} model.ImportResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/alumni/import [post]
+func ImportAlumniService(c *fiber.Ctx, db *mongo.Database) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return model.NewValidationError("File import wajib diunggah")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return model.WrapError(err, "Gagal membuka file")
+	}
+	defer file.Close()
+
+	var rows []ImportRow
+	switch strings.ToLower(filepath.Ext(fileHeader.Filename)) {
+	case ".csv":
+		rows, err = ReadAlumniCSV(file)
+	case ".xlsx":
+		rows, err = ReadAlumniXLSX(file)
+	default:
+		return model.NewValidationError("Format file harus .csv atau .xlsx")
+	}
+	if err != nil {
+		return model.NewValidationError(err.Error())
+	}
+
+	nims := make([]string, 0, len(rows))
+	for _, row := range rows {
+		nims = append(nims, row.Values["nim"])
+	}
+	existing, err := repository.FindAlumniByNIMs(db, nims)
+	if err != nil {
+		return model.WrapError(err, "Gagal memeriksa data alumni")
+	}
+
+	result, toWrite := PlanAlumniImport(rows, existing)
+	result.DryRun = c.QueryBool("dry_run", false)
+
+	if !result.DryRun {
+		if _, _, err := repository.UpsertAlumniByNIM(db, toWrite); err != nil {
+			return model.WrapError(err, "Gagal menyimpan data import")
+		}
+		recordAudit(c, db, model.AuditImport, model.AuditEntityAlumni, "", nil, bson.M{
+			"file":       fileHeader.Filename,
+			"dibuat":     result.Dibuat,
+			"diperbarui": result.Diperbarui,
+			"ditolak":    result.Ditolak,
+		})
+		recordImportRevisions(c, db, existing, toWrite)
+	}
+
+	message := "Import alumni selesai"
+	if result.DryRun {
+		message = "Simulasi import alumni (tidak ada data yang disimpan)"
+	}
+
+	return c.JSON(model.ImportResponse{
+		Message: message,
+		Success: true,
+		Data:    result,
+	})
+}
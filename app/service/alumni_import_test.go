@@ -0,0 +1,107 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+)
+
+const importCSV = `NIM,Nama,Jurusan,Angkatan,Tahun Lulus,Email
+187221001,Budi,Informatika,2018,2022,budi@mail.com
+187221002,Sari,Informatika,2018,2022,sari@mail.com
+abc,,Informatika,2023,2022,bukan-email
+187221001,Budi Lagi,Informatika,2018,2022,budi2@mail.com
+,,,,,
+`
+
+func TestReadAlumniCSV(t *testing.T) {
+	rows, err := ReadAlumniCSV(strings.NewReader(importCSV))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// baris kosong dilewati
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(rows))
+	}
+	if rows[0].Baris != 2 || rows[0].Values["tahun_lulus"] != "2022" {
+		t.Errorf("unexpected first row: %+v", rows[0])
+	}
+}
+
+func TestReadAlumniCSV_MissingNIM(t *testing.T) {
+	_, err := ReadAlumniCSV(strings.NewReader("nama,jurusan\nBudi,Informatika\n"))
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestPlanAlumniImport(t *testing.T) {
+	rows, _ := ReadAlumniCSV(strings.NewReader(importCSV))
+	existing := map[string]model.Alumni{
+		"187221002": {NIM: "187221002", Nama: "Sari", Jurusan: "Informatika", Angkatan: 2018, TahunLulus: 2022, Email: "sari@mail.com"},
+	}
+
+	result, toWrite := PlanAlumniImport(rows, existing)
+
+	if result.Dibuat != 1 || result.TidakBerubah != 1 || result.Ditolak != 2 {
+		t.Errorf("unexpected summary: %+v", result)
+	}
+	if len(toWrite) != 1 || toWrite[0].NIM != "187221001" {
+		t.Errorf("expected only Budi to be written, got %+v", toWrite)
+	}
+
+	invalid := result.Baris[2]
+	if invalid.Aksi != "ditolak" || len(invalid.Errors) != 4 {
+		t.Errorf("expected 4 errors on row 4, got %+v", invalid)
+	}
+	duplicate := result.Baris[3]
+	if duplicate.Aksi != "ditolak" || !strings.Contains(duplicate.Errors[0], "duplikat") {
+		t.Errorf("expected duplicate NIM to be rejected, got %+v", duplicate)
+	}
+}
+
+func TestPlanAlumniImport_Update(t *testing.T) {
+	rows := []ImportRow{{Baris: 2, Values: map[string]string{
+		"nim": "187221002", "nama": "Sari", "jurusan": "Sistem Informasi", "angkatan": "2018",
+	}}}
+	existing := map[string]model.Alumni{
+		"187221002": {NIM: "187221002", Nama: "Sari", Jurusan: "Informatika", Angkatan: 2018},
+	}
+
+	result, toWrite := PlanAlumniImport(rows, existing)
+
+	if result.Diperbarui != 1 || len(toWrite) != 1 {
+		t.Fatalf("expected 1 update, got %+v", result)
+	}
+	if len(result.Baris[0].Perubahan) != 1 || result.Baris[0].Perubahan[0] != "jurusan" {
+		t.Errorf("expected jurusan change, got %v", result.Baris[0].Perubahan)
+	}
+}
+
+func TestPlanAlumniImport_MissingColumnKeepsStored(t *testing.T) {
+	// file tanpa kolom email, no_telepon, dan alamat
+	rows, err := ReadAlumniCSV(strings.NewReader("nim,nama,jurusan,angkatan,tahun_lulus\n187221002,Sari,Sistem Informasi,2018,2022\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	telp, alamat := "08123456789", "Surabaya"
+	existing := map[string]model.Alumni{
+		"187221002": {NIM: "187221002", Nama: "Sari", Jurusan: "Informatika", Angkatan: 2018, TahunLulus: 2022,
+			Email: "sari@mail.com", NoTelp: &telp, Alamat: &alamat},
+	}
+
+	result, toWrite := PlanAlumniImport(rows, existing)
+
+	if result.Diperbarui != 1 || len(toWrite) != 1 {
+		t.Fatalf("expected 1 update, got %+v", result)
+	}
+	if len(result.Baris[0].Perubahan) != 1 || result.Baris[0].Perubahan[0] != "jurusan" {
+		t.Errorf("expected only jurusan change, got %v", result.Baris[0].Perubahan)
+	}
+	got := toWrite[0]
+	if got.Email != "sari@mail.com" || stringValue(got.NoTelp) != telp || stringValue(got.Alamat) != alamat {
+		t.Errorf("expected stored optional fields to be kept, got %+v", got)
+	}
+}
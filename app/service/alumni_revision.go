@@ -0,0 +1,359 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// revisionChanges mengembalikan nama field yang berbeda antara dua kondisi alumni, terurut
+func revisionChanges(before, after *model.Alumni) []string {
+	diff := auditDiff(before, after)
+	if len(diff) == 0 {
+		return nil
+	}
+
+	fields := make([]string, 0, len(diff))
+	for field := range diff {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// newAlumniRevision menyusun revisi dengan pelaku dari token pada request. Snapshot berisi
+// kondisi setelah perubahan; untuk penghapusan berisi kondisi terakhir sebelum dihapus.
+func newAlumniRevision(c *fiber.Ctx, action string, before, after *model.Alumni) *model.AlumniRevision {
+	snapshot := after
+	if snapshot == nil {
+		snapshot = before
+	}
+
+	rev := &model.AlumniRevision{
+		AlumniID:  snapshot.ID,
+		Action:    action,
+		Snapshot:  *snapshot,
+		CreatedAt: time.Now(),
+	}
+	if after != nil {
+		rev.Changes = revisionChanges(before, after)
+	}
+	if userID, ok := c.Locals("user_id").(primitive.ObjectID); ok && !userID.IsZero() {
+		rev.ActorID = &userID
+	}
+	rev.Actor, _ = c.Locals("username").(string)
+	return rev
+}
+
+// saveAlumniRevision menyimpan revisi. Jika alumni belum punya riwayat, kondisi sebelum
+// perubahan disimpan dulu sebagai revisi baseline agar perubahan pertama pun bisa dibatalkan.
+// Seperti audit log, kegagalan hanya dicatat ke log server.
+func saveAlumniRevision(db *mongo.Database, before *model.Alumni, rev *model.AlumniRevision) {
+	if before != nil && rev.Action != model.AuditHardDelete {
+		latest, err := repository.GetLatestAlumniRevision(db, before.ID)
+		if err != nil {
+			log.Printf("Gagal memeriksa revisi alumni %s: %v", before.ID.Hex(), err)
+			return
+		}
+		if latest == nil {
+			baseline := &model.AlumniRevision{
+				AlumniID:  before.ID,
+				Action:    model.RevisionBaseline,
+				Snapshot:  *before,
+				CreatedAt: before.UpdatedAt,
+			}
+			if baseline.CreatedAt.IsZero() {
+				baseline.CreatedAt = time.Now()
+			}
+			if err := repository.CreateAlumniRevision(db, baseline); err != nil {
+				log.Printf("Gagal menyimpan revisi baseline alumni %s: %v", before.ID.Hex(), err)
+				return
+			}
+		}
+	}
+
+	if err := repository.CreateAlumniRevision(db, rev); err != nil {
+		log.Printf("Gagal menyimpan revisi alumni %s: %v", rev.AlumniID.Hex(), err)
+	}
+}
+
+// recordAlumniRevision mencatat snapshot alumni setelah perubahan oleh user pemilik token
+func recordAlumniRevision(c *fiber.Ctx, db *mongo.Database, action string, before, after *model.Alumni) {
+	if before == nil && after == nil {
+		return
+	}
+	saveAlumniRevision(db, before, newAlumniRevision(c, action, before, after))
+}
+
+// recordImportRevisions mencatat revisi untuk setiap alumni yang dibuat atau diperbarui import
+func recordImportRevisions(c *fiber.Ctx, db *mongo.Database, existing map[string]model.Alumni, written []model.Alumni) {
+	nims := make([]string, 0, len(written))
+	for _, a := range written {
+		nims = append(nims, a.NIM)
+	}
+	saved, err := repository.FindAlumniByNIMs(db, nims)
+	if err != nil {
+		log.Printf("Gagal mengambil data alumni hasil import untuk revisi: %v", err)
+		return
+	}
+
+	for _, nim := range nims {
+		after, ok := saved[nim]
+		if !ok {
+			continue
+		}
+		var before *model.Alumni
+		if prev, ok := existing[nim]; ok {
+			before = &prev
+		}
+		recordAlumniRevision(c, db, model.AuditImport, before, &after)
+	}
+}
+
+// alumniHistory -> alumni yang riwayat revisinya diakses. current nil berarti alumni sudah
+// dihapus dan hanya dikenali dari revisinya.
+type alumniHistory struct {
+	id      primitive.ObjectID
+	current *model.Alumni
+	latest  *model.AlumniRevision
+}
+
+// requireAlumniHistory mengambil alumni dari parameter :id beserta revisi terakhirnya. Staff
+// hanya bisa mengakses alumni pada jurusannya; alumni yang sudah dihapus diperiksa memakai
+// jurusan pada snapshot terakhir. Hasil nil berarti request ditolak dengan error.
+func requireAlumniHistory(c *fiber.Ctx, db *mongo.Database) (*alumniHistory, error) {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return nil, model.NewValidationError("ID alumni tidak valid")
+	}
+
+	// alumni yang sudah dihapus tetap punya riwayat, sehingga current boleh kosong
+	current, err := repository.GetAlumniByID(db, id.Hex())
+	if err != nil && !errors.Is(err, repository.ErrAlumniNotFound) {
+		return nil, model.WrapError(err, "Gagal mengambil data alumni")
+	}
+	latest, err := repository.GetLatestAlumniRevision(db, id)
+	if err != nil {
+		return nil, model.WrapError(err, "Gagal mengambil riwayat revisi alumni")
+	}
+
+	var jurusan string
+	switch {
+	case current != nil:
+		jurusan = current.Jurusan
+	case latest != nil:
+		jurusan = latest.Snapshot.Jurusan
+	}
+	if (current == nil && latest == nil) || !jurusanScope(c).Allows(jurusan) {
+		return nil, model.NewNotFoundError("Alumni tidak ditemukan")
+	}
+
+	return &alumniHistory{id: id, current: current, latest: latest}, nil
+}
+
+// parseRevisionVersion membaca nomor versi dari query atau parameter. Nilai kosong
+// menghasilkan def.
+func parseRevisionVersion(name, raw string, def int) (int, error) {
+	if raw == "" {
+		return def, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < 1 {
+		return 0, fmt.Errorf("%s harus berupa nomor versi (bilangan bulat positif)", name)
+	}
+	return v, nil
+}
+
+// GetAlumniRevisionsService godoc
+// @Summary Melihat riwayat revisi alumni
+// @Description Mengembalikan snapshot data alumni untuk setiap perubahan (create, update, import, hapus permanen, rollback), terbaru lebih dulu kecuali order=asc. Revisi baseline menyimpan kondisi sebelum perubahan pertama yang tercatat. Riwayat alumni yang sudah dihapus tetap bisa dilihat. Staff hanya bisa melihat alumni pada jurusannya.
+// @Tags Alumni
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Alumni"
+// @Param page query int false "Nomor halaman" default(1)
+// @Param limit query int false "Jumlah data per halaman (maks 100)" default(10)
+// @Param order query string false "Urutan versi" Enums(asc, desc) default(desc)
+// @Success 200 {object} model.AlumniRevisionListResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/alumni/{id}/revisions [get]
+func GetAlumniRevisionsService(c *fiber.Ctx, db *mongo.Database) error {
+	history, err := requireAlumniHistory(c, db)
+	if history == nil {
+		return err
+	}
+
+	meta, _ := parseListQuery(c, map[string]string{"version": "version"}, "version")
+	if c.Query("order") == "" {
+		meta.Order = "desc"
+	}
+
+	revisions, err := repository.GetAlumniRevisions(db, history.id, meta.Order, meta.Limit, pageOffset(meta))
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil riwayat revisi alumni")
+	}
+
+	total, err := repository.CountAlumniRevisions(db, history.id)
+	if err != nil {
+		return model.WrapError(err, "Gagal menghitung riwayat revisi alumni")
+	}
+
+	return c.JSON(model.AlumniRevisionListResponse{
+		Message: "Berhasil mengambil riwayat revisi alumni",
+		Success: true,
+		Data:    revisions,
+		Meta:    withTotal(meta, total),
+	})
+}
+
+// DiffAlumniRevisionsService godoc
+// @Summary Membandingkan dua revisi alumni
+// @Description Mengembalikan field yang berbeda antara revisi from dan to beserta nilai sebelum/sesudahnya. Default to adalah versi terakhir dan from adalah versi sebelum to.
+// @Tags Alumni
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Alumni"
+// @Param from query int false "Versi awal"
+// @Param to query int false "Versi akhir"
+// @Success 200 {object} model.AlumniRevisionDiffResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/alumni/{id}/revisions/diff [get]
+func DiffAlumniRevisionsService(c *fiber.Ctx, db *mongo.Database) error {
+	history, err := requireAlumniHistory(c, db)
+	if history == nil {
+		return err
+	}
+	if history.latest == nil {
+		return model.NewNotFoundError("Alumni belum memiliki riwayat revisi")
+	}
+
+	to, err := parseRevisionVersion("to", c.Query("to"), history.latest.Version)
+	var from int
+	if err == nil {
+		from, err = parseRevisionVersion("from", c.Query("from"), to-1)
+	}
+	if err == nil && from < 1 {
+		err = fmt.Errorf("tidak ada versi sebelum versi %d, isi from", to)
+	}
+	if err != nil {
+		return model.NewValidationError(err.Error())
+	}
+
+	revisions := map[int]*model.AlumniRevision{}
+	for _, v := range []int{from, to} {
+		rev, err := repository.GetAlumniRevision(db, history.id, v)
+		if err != nil {
+			return model.WrapError(err, "Gagal mengambil revisi alumni")
+		}
+		if rev == nil {
+			return model.NewNotFoundError(fmt.Sprintf("Revisi versi %d tidak ditemukan", v))
+		}
+		revisions[v] = rev
+	}
+
+	changes := auditDiff(&revisions[from].Snapshot, &revisions[to].Snapshot)
+	if changes == nil {
+		changes = map[string]model.AuditChange{}
+	}
+
+	return c.JSON(model.AlumniRevisionDiffResponse{
+		Message: "Berhasil membandingkan revisi alumni",
+		Success: true,
+		Data: model.AlumniRevisionDiff{
+			AlumniID: history.id,
+			From:     from,
+			To:       to,
+			Changes:  changes,
+		},
+	})
+}
+
+// RollbackAlumniService godoc
+// @Summary Mengembalikan alumni ke revisi tertentu
+// @Description Mengembalikan data alumni ke snapshot pada versi yang dipilih dan mencatatnya sebagai revisi baru. Alumni yang sudah dihapus permanen dibuat ulang dengan ID yang sama (hanya untuk user dengan akses penuh); alumni di trash harus direstore dulu. Staff hanya bisa mengembalikan alumni ke revisi yang jurusannya masih dalam cakupannya.
+// @Tags Alumni
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Alumni"
+// @Param version path int true "Versi tujuan"
+// @Success 200 {object} model.SingleAlumniResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/alumni/{id}/revisions/{version}/rollback [post]
+func RollbackAlumniService(c *fiber.Ctx, db *mongo.Database) error {
+	history, err := requireAlumniHistory(c, db)
+	if history == nil {
+		return err
+	}
+
+	version, err := parseRevisionVersion("version", c.Params("version"), 0)
+	if err != nil {
+		return model.NewValidationError(err.Error())
+	}
+
+	target, err := repository.GetAlumniRevision(db, history.id, version)
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil revisi alumni")
+	}
+	if target == nil {
+		return model.NewNotFoundError(fmt.Sprintf("Revisi versi %d tidak ditemukan", version))
+	}
+
+	if history.current == nil {
+		_, err := repository.GetDeletedAlumniByID(db, history.id.Hex())
+		if err == nil {
+			return model.NewConflictError("Alumni ada di trash, restore terlebih dahulu sebelum rollback")
+		}
+		if !errors.Is(err, repository.ErrAlumniNotInTrash) {
+			return model.WrapError(err, "Gagal mengambil data alumni")
+		}
+	}
+	if history.current == nil && permissionScope(c) != model.ScopeAll {
+		return model.NewForbiddenError("Hanya user dengan akses penuh yang bisa memulihkan alumni yang sudah dihapus")
+	}
+	if !jurusanScope(c).Allows(target.Snapshot.Jurusan) {
+		return model.NewForbiddenError("Jurusan pada revisi tersebut di luar cakupan Anda")
+	}
+
+	// NIM pada snapshot lama bisa saja sudah dipakai alumni lain sejak revisi itu dibuat
+	if err := checkNIMAvailable(db, target.Snapshot.NIM, history.id); err != nil {
+		return err
+	}
+
+	restored, err := repository.RestoreAlumniSnapshot(db, target.Snapshot)
+	if err != nil {
+		return model.WrapError(err, "Gagal rollback alumni")
+	}
+
+	rev := newAlumniRevision(c, model.AuditRollback, history.current, restored)
+	rev.RestoredFrom = version
+	saveAlumniRevision(db, history.current, rev)
+	recordAudit(c, db, model.AuditRollback, model.AuditEntityAlumni, history.id.Hex(), history.current, restored)
+
+	return c.JSON(model.SingleAlumniResponse{
+		Message: fmt.Sprintf("Alumni berhasil dikembalikan ke versi %d", version),
+		Success: true,
+		Alumni:  *restored,
+	})
+}
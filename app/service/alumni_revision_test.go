@@ -0,0 +1,83 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestRevisionChanges(t *testing.T) {
+	id := primitive.NewObjectID()
+	alamat := "Surabaya"
+	before := &model.Alumni{ID: id, NIM: "187221001", Nama: "Budi", Jurusan: "Informatika", UpdatedAt: time.Now()}
+	after := &model.Alumni{ID: id, NIM: "187221001", Nama: "Budi Santoso", Jurusan: "Informatika", Alamat: &alamat, UpdatedAt: time.Now().Add(time.Minute)}
+
+	got := revisionChanges(before, after)
+	if want := []string{"alamat", "nama"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("revisionChanges() = %v, want %v", got, want)
+	}
+
+	if got := revisionChanges(after, after); got != nil {
+		t.Errorf("expected no changes, got %v", got)
+	}
+}
+
+func TestParseRevisionVersion(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    int
+		wantErr bool
+	}{
+		{"", 7, false},
+		{"3", 3, false},
+		{"0", 0, true},
+		{"-1", 0, true},
+		{"dua", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseRevisionVersion("from", tt.raw, 7)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("%q: got %d, want %d", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestCheckNIMAvailable(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	self := primitive.NewObjectID()
+
+	mt.Run("nim kosong tidak diperiksa", func(mt *mtest.T) {
+		if err := checkNIMAvailable(mt.DB, "", self); err != nil {
+			mt.Errorf("unexpected error: %v", err)
+		}
+		if events := mt.GetAllStartedEvents(); len(events) != 0 {
+			mt.Errorf("nim kosong tidak boleh dicocokkan ke alumni lain, got %d command", len(events))
+		}
+	})
+
+	mt.Run("dipakai alumni lain", func(mt *mtest.T) {
+		mt.AddMockResponses(cursor(mt, "alumni", bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "nim", Value: "187221001"}}))
+
+		if err := checkNIMAvailable(mt.DB, "187221001", self); model.ErrorKindOf(err) != model.ErrKindConflict {
+			mt.Errorf("expected conflict, got %v", err)
+		}
+	})
+
+	mt.Run("hanya dipakai dirinya sendiri", func(mt *mtest.T) {
+		mt.AddMockResponses(cursor(mt, "alumni", bson.D{{Key: "_id", Value: self}, {Key: "nim", Value: "187221001"}}))
+
+		if err := checkNIMAvailable(mt.DB, "187221001", self); err != nil {
+			mt.Errorf("unexpected error: %v", err)
+		}
+	})
+}
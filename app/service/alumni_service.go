@@ -1,23 +1,45 @@
 package service
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/noorfarihaf11/clean-arc/app/model"
 	"github.com/noorfarihaf11/clean-arc/app/repository"
 	"github.com/noorfarihaf11/clean-arc/utils"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// alumniSortFields adalah daftar field yang boleh dipakai untuk sorting alumni
+var alumniSortFields = map[string]string{
+	"id":          "_id",
+	"nama":        "nama",
+	"nim":         "nim",
+	"jurusan":     "jurusan",
+	"angkatan":    "angkatan",
+	"tahun_lulus": "tahun_lulus",
+	"created_at":  "created_at",
+}
+
 // GetAllAlumniService godoc
 // @Summary Mengambil semua data alumni
-// @Description Mengembalikan daftar semua alumni yang terdaftar dalam sistem
+// @Description Mengembalikan daftar alumni dengan pagination, pencarian (nama/nim/jurusan), dan sorting. Staff hanya melihat alumni pada jurusannya.
 // @Tags Alumni
 // @Accept json
 // @Produce json
+// @Param page query int false "Nomor halaman" default(1)
+// @Param limit query int false "Jumlah data per halaman (maks 100)" default(10)
+// @Param sortBy query string false "Field sorting" Enums(id, nama, nim, jurusan, angkatan, tahun_lulus, created_at) default(id)
+// @Param order query string false "Urutan sorting" Enums(asc, desc) default(asc)
+// @Param search query string false "Kata kunci pencarian nama, nim, atau jurusan"
 // @Success 200 {object} model.AlumniResponse "Berhasil mengambil data alumni"
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
@@ -25,40 +47,38 @@ import (
 func GetAllAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 	authHeader := c.Get("Authorization")
 	if authHeader == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Authorization header tidak ada",
-			"success": false,
-		})
+		return model.NewUnauthorizedError("Authorization header tidak ada")
 	}
 
 	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 	if tokenString == authHeader {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Format Authorization salah, gunakan Bearer <token>",
-			"success": false,
-		})
+		return model.NewUnauthorizedError("Format Authorization salah, gunakan Bearer <token>")
 	}
 
 	_, err := utils.ValidateToken(tokenString)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Token tidak valid: " + err.Error(),
-			"success": false,
-		})
+		return model.NewUnauthorizedError("Token tidak valid: " + err.Error())
 	}
 
-	alumniList, err := repository.GetAllAlumni(db)
+	meta, sortField := parseListQuery(c, alumniSortFields, "id")
+
+	scope := jurusanScope(c)
+
+	alumniList, err := repository.GetAlumniRepo(db, meta.Search, scope, sortField, meta.Order, meta.Limit, pageOffset(meta))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data alumni: " + err.Error(),
-			"success": false,
-		})
+		return model.WrapError(err, "Gagal mengambil data alumni")
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Berhasil mendapatkan semua data alumni",
-		"success": true,
-		"alumni":  alumniList,
+	total, err := repository.CountAlumniRepo(db, meta.Search, scope)
+	if err != nil {
+		return model.WrapError(err, "Gagal menghitung data alumni")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.AlumniResponse{
+		Message: "Berhasil mendapatkan data alumni",
+		Success: true,
+		Data:    alumniList,
+		Meta:    withTotal(meta, total),
 	})
 }
 
@@ -79,27 +99,12 @@ func GetAlumniByIDService(c *fiber.Ctx, db *mongo.Database) error {
 	id := c.Params("id")
 
 	if _, err := primitive.ObjectIDFromHex(id); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
-			Success: false,
-			Message: "ID tidak valid",
-			Code:    fiber.StatusBadRequest,
-		})
+		return model.NewValidationError("ID tidak valid")
 	}
 
 	alumni, err := repository.GetAlumniByID(db, id)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{
-				Success: false,
-				Message: "Alumni tidak ditemukan",
-				Code:    fiber.StatusNotFound,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
-			Success: false,
-			Message: "Gagal mengambil data alumni",
-			Code:    fiber.StatusInternalServerError,
-		})
+		return model.WrapError(err, "Gagal mengambil data alumni")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -112,7 +117,7 @@ func GetAlumniByIDService(c *fiber.Ctx, db *mongo.Database) error {
 
 // CreateAlumniService godoc
 // @Summary Menambahkan data alumni baru
-// @Description Menambahkan data alumni baru ke dalam sistem
+// @Description Menambahkan data alumni baru ke dalam sistem. NIM harus unik di antara alumni aktif. Dengan permission alumni:create:own (tidak diberikan policy bawaan) data alumni langsung ditautkan ke akun pemilik token, dan ditolak jika akun sudah punya profil atau NIM sudah terdaftar; dengan alumni:create:jurusan jurusan alumni harus termasuk jurusan user.
 // @Tags Alumni
 // @Accept json
 // @Produce json
@@ -120,58 +125,46 @@ func GetAlumniByIDService(c *fiber.Ctx, db *mongo.Database) error {
 // @Success 201 {object} model.SingleAlumniResponse "Berhasil menambahkan data alumni"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse "NIM sudah terdaftar atau akun sudah punya profil"
 // @Failure 500 {object} model.ErrorResponse
 // @Router /unair/alumni [post]
 func CreateAlumniService(c *fiber.Ctx, db *mongo.Database) error {
-	authHeader := c.Get("Authorization")
-	if authHeader == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Authorization header tidak ada",
-			"success": false,
-		})
-	}
-
-	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-	if tokenString == authHeader {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Format Authorization salah, gunakan Bearer <token>",
-			"success": false,
-		})
-	}
-
-	claims, err := utils.ValidateToken(tokenString)
-	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Token tidak valid: " + err.Error(),
-			"success": false,
-		})
-	}
-
 	var alumni model.Alumni
 	if err := c.BodyParser(&alumni); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Request body tidak valid",
-			"success": false,
-		})
+		return model.NewValidationError("Request body tidak valid")
 	}
 
 	var userID *primitive.ObjectID
-	if claims.Role == "admin" {
-		log.Printf("Admin %s menambah alumni baru", claims.Username)
+	switch permissionScope(c) {
+	case model.ScopeAll:
+		userID = nil
+	case model.ScopeJurusan:
+		if !jurusanScope(c).Allows(alumni.Jurusan) {
+			return model.NewForbiddenError("Jurusan alumni di luar cakupan Anda")
+		}
 		userID = nil
-	} else {
-		log.Printf("User %s (alumni) menambah data dirinya sendiri", claims.Username)
-		userID = &claims.UserID
+	default:
+		// alumni menambah data dirinya sendiri (hanya jika policy kustom memberi alumni:create:own;
+		// policy bawaan tidak, karena profil berasal dari registrasi atau klaim NIM)
+		ownID, ok := c.Locals("user_id").(primitive.ObjectID)
+		if !ok {
+			return model.NewUnauthorizedError("User tidak dikenali")
+		}
+		if err := checkOwnAlumniCreate(db, ownID, alumni.NIM); err != nil {
+			return err
+		}
+		userID = &ownID
 	}
 
 	savedAlumni, err := repository.CreateAlumni(db, &alumni, userID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal menambahkan alumni: " + err.Error(),
-			"success": false,
-		})
+		return model.WrapError(err, "Gagal menambahkan alumni")
 	}
 
+	recordAudit(c, db, model.AuditCreate, model.AuditEntityAlumni, savedAlumni.ID.Hex(), nil, savedAlumni)
+	recordAlumniRevision(c, db, model.AuditCreate, nil, savedAlumni)
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"message": "Alumni berhasil ditambahkan",
 		"success": true,
@@ -179,9 +172,31 @@ func CreateAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 	})
 }
 
+// checkOwnAlumniCreate menolak pembuatan profil oleh alumni sendiri jika akunnya sudah punya
+// profil, atau NIM-nya sudah ada di data alumni. NIM yang sudah ada harus diklaim lewat
+// registrasi agar verifikasi email atau kode klaim tidak bisa dilewati.
+func checkOwnAlumniCreate(db *mongo.Database, userID primitive.ObjectID, nim string) error {
+	_, err := repository.GetAlumniByUserID(db, userID)
+	if err == nil {
+		return repository.ErrAlumniProfileExists
+	}
+	if !errors.Is(err, repository.ErrAlumniNotFound) {
+		return model.WrapError(err, "Gagal memeriksa profil alumni")
+	}
+
+	matches, err := repository.FindAlumniByNIM(db, strings.TrimSpace(nim))
+	if err != nil {
+		return model.WrapError(err, "Gagal memeriksa NIM")
+	}
+	if len(matches) > 0 {
+		return model.NewConflictError("NIM sudah terdaftar, klaim data alumni lewat registrasi")
+	}
+	return nil
+}
+
 // UpdateAlumniService godoc
 // @Summary Mengubah data alumni
-// @Description Mengubah data alumni yang sudah ada dalam sistem
+// @Description Mengubah data alumni yang sudah ada dalam sistem. Staff hanya bisa mengubah alumni pada jurusannya dan tidak bisa memindahkannya ke jurusan lain. Jika jurusan tidak dikirim, jurusan yang tersimpan dipertahankan.
 // @Tags Alumni
 // @Accept json
 // @Produce json
@@ -190,59 +205,61 @@ func CreateAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 // @Success 200 {object} model.Alumni
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /unair/alumni/{id} [put]
 func UpdateAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 	authHeader := c.Get("Authorization")
 	if authHeader == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Authorization header tidak ada",
-			"success": false,
-		})
+		return model.NewUnauthorizedError("Authorization header tidak ada")
 	}
 
 	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 	if tokenString == authHeader {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Format Authorization salah, gunakan Bearer <token>",
-			"success": false,
-		})
+		return model.NewUnauthorizedError("Format Authorization salah, gunakan Bearer <token>")
 	}
 
 	_, err := utils.ValidateToken(tokenString)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Token tidak valid: " + err.Error(),
-			"success": false,
-		})
+		return model.NewUnauthorizedError("Token tidak valid: " + err.Error())
 	}
 
 	id := c.Params("id")
 	if _, err := primitive.ObjectIDFromHex(id); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "ID tidak valid",
-			"success": false,
-		})
+		return model.NewValidationError("ID tidak valid")
 	}
 
 	var alumni model.Alumni
 	if err := c.BodyParser(&alumni); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Request body tidak valid",
-			"success": false,
-		})
+		return model.NewValidationError("Request body tidak valid")
+	}
+
+	before, err := repository.GetAlumniByID(db, id)
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil data alumni")
+	}
+
+	// cakupan staff diperiksa dari jurusan yang tersimpan; alumni di luar cakupan dianggap
+	// tidak ditemukan seperti pada UpdateAlumni
+	scope := jurusanScope(c)
+	if !scope.Allows(before.Jurusan) {
+		return repository.ErrAlumniNotFound
+	}
+	if alumni.Jurusan == "" {
+		alumni.Jurusan = before.Jurusan
+	} else if !scope.Allows(alumni.Jurusan) {
+		// staff tidak boleh memindahkan alumni ke jurusan di luar cakupannya
+		return model.NewForbiddenError("Jurusan alumni di luar cakupan Anda")
 	}
 
-	updatedAlumni, err := repository.UpdateAlumni(db, id, &alumni)
+	updatedAlumni, err := repository.UpdateAlumni(db, id, &alumni, scope)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengupdate alumni: " + err.Error(),
-			"success": false,
-		})
+		return model.WrapError(err, "Gagal mengupdate alumni")
 	}
 
-	username, _ := c.Locals("username").(string)
-	log.Printf("User %s mengubah data alumni ID %s", username, id)
+	recordAudit(c, db, model.AuditUpdate, model.AuditEntityAlumni, id, before, updatedAlumni)
+	recordAlumniRevision(c, db, model.AuditUpdate, before, updatedAlumni)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Alumni berhasil diperbarui",
@@ -252,8 +269,8 @@ func UpdateAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 }
 
 // DeleteAlumniService godoc
-// @Summary Menghapus data alumni
-// @Description Menghapus data alumni dari sistem
+// @Summary Memindahkan data alumni ke trash
+// @Description Memindahkan alumni ke trash (soft delete) bersama pekerjaannya yang masih aktif. Alumni di trash tidak muncul di daftar, statistik, maupun export dan bisa dipulihkan lewat restore.
 // @Tags Alumni
 // @Accept json
 // @Produce json
@@ -261,53 +278,288 @@ func UpdateAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 // @Success 201 {object} model.SingleAlumniResponse "Berhasil menghapus data alumni"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /unair/alumni/{id} [delete]
 func DeleteAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 	authHeader := c.Get("Authorization")
 	if authHeader == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Authorization header tidak ada",
-			"success": false,
-		})
+		return model.NewUnauthorizedError("Authorization header tidak ada")
 	}
 
 	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 	if tokenString == authHeader {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Format Authorization salah, gunakan Bearer <token>",
-			"success": false,
-		})
+		return model.NewUnauthorizedError("Format Authorization salah, gunakan Bearer <token>")
 	}
 
 	_, err := utils.ValidateToken(tokenString)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Token tidak valid: " + err.Error(),
-			"success": false,
-		})
+		return model.NewUnauthorizedError("Token tidak valid: " + err.Error())
+	}
+
+	id := c.Params("id")
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return model.NewValidationError("ID tidak valid")
+	}
+
+	var deletedBy *primitive.ObjectID
+	if userID, ok := c.Locals("user_id").(primitive.ObjectID); ok {
+		deletedBy = &userID
+	}
+
+	if _, err := repository.SoftDeleteAlumni(db, id, deletedBy); err != nil {
+		return model.WrapError(err, "Gagal menghapus alumni")
+	}
+
+	recordAudit(c, db, model.AuditSoftDelete, model.AuditEntityAlumni, id, bson.M{"is_deleted": false}, bson.M{"is_deleted": true})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Alumni berhasil dipindahkan ke trash",
+		"success": true,
+	})
+}
+
+// alumniTrashSortFields -> field sorting yang diizinkan pada listing trash alumni
+var alumniTrashSortFields = map[string]string{
+	"deleted_at": "deleted_at",
+	"nama":       "nama",
+	"nim":        "nim",
+	"jurusan":    "jurusan",
+}
+
+// GetAlumniTrashService godoc
+// @Summary Mendapatkan daftar alumni di trash
+// @Description Mengambil alumni yang sudah dihapus (soft delete) beserta jumlah pekerjaan yang ikut terhapus bersamanya, dengan pagination, sorting, dan pencarian. Default terbaru dihapus lebih dulu.
+// @Tags Trash
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Nomor halaman" default(1)
+// @Param limit query int false "Jumlah data per halaman (maks 100)" default(10)
+// @Param sortBy query string false "Field sorting" Enums(deleted_at, nama, nim, jurusan) default(deleted_at)
+// @Param order query string false "Urutan sorting" Enums(asc, desc) default(desc)
+// @Param search query string false "Kata kunci nama, nim, atau jurusan"
+// @Success 200 {object} model.AlumniTrashResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/alumni/filter/trash [get]
+func GetAlumniTrashService(c *fiber.Ctx, db *mongo.Database) error {
+	meta, sortField := parseListQuery(c, alumniTrashSortFields, "deleted_at")
+	if c.Query("order") == "" {
+		meta.Order = "desc"
+	}
+
+	trash, total, err := repository.GetAlumniTrash(db, meta.Search, sortField, meta.Order, meta.Limit, pageOffset(meta))
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil trash alumni")
+	}
+
+	return c.JSON(model.AlumniTrashResponse{
+		Message: "Data trash alumni berhasil diambil",
+		Success: true,
+		Data:    trash,
+		Meta:    withTotal(meta, total),
+	})
+}
+
+// checkNIMAvailable menolak dengan conflict jika NIM sudah dipakai alumni aktif selain self.
+// NIM kosong (profil awal akun alumni yang dibuat admin) tidak pernah bentrok.
+func checkNIMAvailable(db *mongo.Database, nim string, self primitive.ObjectID) error {
+	if nim == "" {
+		return nil
+	}
+
+	sameNIM, err := repository.FindAlumniByNIM(db, nim)
+	if err != nil {
+		return model.WrapError(err, "Gagal memeriksa NIM")
+	}
+	for _, other := range sameNIM {
+		if other.ID != self {
+			return model.NewConflictError(fmt.Sprintf("NIM %s sudah dipakai alumni lain", nim))
+		}
+	}
+	return nil
+}
+
+// RestoreAlumniService godoc
+// @Summary Mengembalikan alumni dari trash
+// @Description Memulihkan alumni beserta pekerjaan yang ikut terhapus bersamanya. Pekerjaan yang sudah ada di trash sebelum alumninya dihapus tetap di trash. Ditolak jika NIM alumni sudah dipakai alumni aktif lain.
+// @Tags Trash
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Alumni"
+// @Success 200 {object} model.SingleAlumniResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/alumni/filter/restore/{id} [put]
+func RestoreAlumniService(c *fiber.Ctx, db *mongo.Database) error {
+	id := c.Params("id")
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return model.NewValidationError("ID tidak valid")
+	}
+
+	trashed, err := repository.GetDeletedAlumniByID(db, id)
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil data alumni")
+	}
+
+	// selama alumni di trash, NIM-nya bisa dipakai lagi oleh alumni baru atau hasil import
+	if err := checkNIMAvailable(db, trashed.NIM, trashed.ID); err != nil {
+		return err
+	}
+
+	restored, jobs, err := repository.RestoreAlumni(db, id)
+	if err != nil {
+		return model.WrapError(err, "Gagal restore alumni")
 	}
 
+	recordAudit(c, db, model.AuditRestore, model.AuditEntityAlumni, id, bson.M{"is_deleted": true}, bson.M{"is_deleted": false})
+
+	return c.JSON(model.SingleAlumniResponse{
+		Message: fmt.Sprintf("Alumni berhasil direstore bersama %d pekerjaan", jobs),
+		Success: true,
+		Alumni:  *restored,
+	})
+}
+
+// PurgeAlumniService godoc
+// @Summary Menghapus permanen alumni dari trash
+// @Description Menghapus permanen alumni yang ada di trash beserta semua pekerjaannya dan file milik akun yang tertaut (metadata dan file di storage). Kondisi terakhir alumni tetap tersimpan di riwayat revisi.
+// @Tags Trash
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Alumni"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/alumni/filter/delete/{id} [delete]
+func PurgeAlumniService(c *fiber.Ctx, db *mongo.Database) error {
 	id := c.Params("id")
 	if _, err := primitive.ObjectIDFromHex(id); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "ID tidak valid",
-			"success": false,
-		})
+		return model.NewValidationError("ID tidak valid")
+	}
+
+	before, err := repository.GetDeletedAlumniByID(db, id)
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil data alumni")
+	}
+
+	result, err := repository.PurgeAlumni(db, id)
+	if err != nil {
+		return model.WrapError(err, "Gagal menghapus alumni")
+	}
+
+	// file fisik dihapus setelah datanya terhapus; file yang gagal dihapus hanya dicatat
+	for _, f := range result.Files {
+		if err := os.Remove(f.FilePath); err != nil && !os.IsNotExist(err) {
+			log.Printf("Gagal menghapus file %s milik alumni %s: %v", f.FilePath, id, err)
+		}
+	}
+
+	recordAudit(c, db, model.AuditHardDelete, model.AuditEntityAlumni, id, before, nil)
+	recordAlumniRevision(c, db, model.AuditHardDelete, before, nil)
+
+	return c.JSON(fiber.Map{
+		"message":   "Alumni berhasil dihapus permanen",
+		"success":   true,
+		"pekerjaan": result.Pekerjaan,
+		"file":      len(result.Files),
+	})
+}
+
+// GetAlumniBySalaryService godoc
+// @Summary Mengambil alumni dengan gaji tinggi
+// @Description Mengembalikan alumni beserta pekerjaan yang gajinya di atas batas min_gaji
+// @Tags Alumni
+// @Produce json
+// @Param min_gaji query int false "Batas gaji minimum (eksklusif)" default(19000000)
+// @Param mata_uang query string false "Mata uang gaji" default(IDR)
+// @Success 200 {array} model.AlumniWithSalary
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/alumni/filter/high-salary [get]
+func GetAlumniBySalaryService(c *fiber.Ctx, db *mongo.Database) error {
+	minGaji, err := strconv.ParseInt(c.Query("min_gaji", "19000000"), 10, 64)
+	if err != nil || minGaji < 0 {
+		return model.NewValidationError("min_gaji harus berupa angka positif")
 	}
 
-	if err := repository.DeleteAlumni(db, id); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal menghapus alumni: " + err.Error(),
-			"success": false,
-		})
+	mataUang := strings.ToUpper(c.Query("mata_uang", "IDR"))
+
+	results, err := repository.GetAlumniWithHighSalary(db, minGaji, mataUang, jurusanScope(c))
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil data alumni")
 	}
 
-	username, _ := c.Locals("username").(string)
-	log.Printf("User %s menghapus data alumni ID %s", username, id)
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": fmt.Sprintf("Berhasil mendapatkan alumni dengan gaji di atas %d", minGaji),
+		"success": true,
+		"data":    results,
+	})
+}
+
+// GetAlumniByYearService godoc
+// @Summary Mengambil alumni berdasarkan tahun lulus
+// @Description Mengembalikan alumni yang lulus pada tahun tertentu (default tahun berjalan)
+// @Tags Alumni
+// @Produce json
+// @Param tahun query int false "Tahun lulus"
+// @Success 200 {array} model.Alumni
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/alumni/filter/year [get]
+func GetAlumniByYearService(c *fiber.Ctx, db *mongo.Database) error {
+	year := c.QueryInt("tahun", time.Now().Year())
+	if year <= 0 {
+		return model.NewValidationError("tahun tidak valid")
+	}
+
+	alumniList, err := repository.GetAllAlumniByYear(db, year, jurusanScope(c))
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil data alumni")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": fmt.Sprintf("Berhasil mendapatkan alumni lulusan %d", year),
+		"success": true,
+		"data":    alumniList,
+	})
+}
+
+// GetAlumniWithYearService godoc
+// @Summary Mengambil alumni yang langsung bekerja di tahun kelulusan
+// @Description Mengembalikan alumni yang tanggal mulai kerjanya berada di tahun yang sama dengan tahun lulus
+// @Tags Alumni
+// @Produce json
+// @Param tahun query int false "Batasi pada tahun lulus tertentu"
+// @Success 200 {array} model.AlumniWithYear
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/alumni/filter/yearjob [get]
+func GetAlumniWithYearService(c *fiber.Ctx, db *mongo.Database) error {
+	year := c.QueryInt("tahun", 0)
+	if year < 0 {
+		return model.NewValidationError("tahun tidak valid")
+	}
+
+	results, err := repository.GetAlumniWithYear(db, year, jurusanScope(c))
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil data alumni")
+	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Alumni berhasil dihapus",
+		"message": "Berhasil mendapatkan alumni yang bekerja di tahun kelulusan",
 		"success": true,
+		"data":    results,
 	})
 }
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// auditIgnoredFields tidak dicatat pada diff: _id sudah ada di entity_id, updated_at selalu
+// berubah, dan hash password tidak boleh tersimpan di audit log
+var auditIgnoredFields = map[string]bool{
+	"_id":           true,
+	"updated_at":    true,
+	"password_hash": true,
+}
+
+var auditActions = map[string]bool{
+	model.AuditCreate:     true,
+	model.AuditUpdate:     true,
+	model.AuditSoftDelete: true,
+	model.AuditRestore:    true,
+	model.AuditHardDelete: true,
+	model.AuditUpload:     true,
+	model.AuditImport:     true,
+	model.AuditRollback:   true,
+}
+
+// auditDocument mengubah struct menjadi dokumen BSON agar nama field sama dengan di database
+func auditDocument(v interface{}) bson.M {
+	if v == nil {
+		return nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil
+	}
+	if doc, ok := v.(bson.M); ok {
+		return doc
+	}
+
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var doc bson.M
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil
+	}
+	return doc
+}
+
+// auditDiff membandingkan data sebelum dan sesudah perubahan dan hanya mengembalikan field
+// yang berbeda. before nil berarti data baru dibuat, after nil berarti data dihapus.
+func auditDiff(before, after interface{}) map[string]model.AuditChange {
+	b, a := auditDocument(before), auditDocument(after)
+
+	changes := map[string]model.AuditChange{}
+	for k, av := range a {
+		if auditIgnoredFields[k] {
+			continue
+		}
+		bv, ok := b[k]
+		if !ok || !reflect.DeepEqual(bv, av) {
+			changes[k] = model.AuditChange{Before: bv, After: av}
+		}
+	}
+	for k, bv := range b {
+		if _, ok := a[k]; ok || auditIgnoredFields[k] {
+			continue
+		}
+		changes[k] = model.AuditChange{Before: bv}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+	return changes
+}
+
+// newAuditLog menyusun entri audit log dengan pelaku dari token pada request
+func newAuditLog(c *fiber.Ctx, action, entityType, entityID string, before, after interface{}) *model.AuditLog {
+	entry := &model.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    auditDiff(before, after),
+		IP:         c.IP(),
+		Timestamp:  time.Now(),
+	}
+	if userID, ok := c.Locals("user_id").(primitive.ObjectID); ok && !userID.IsZero() {
+		entry.ActorID = &userID
+	}
+	entry.Actor, _ = c.Locals("username").(string)
+	entry.Role, _ = c.Locals("role").(string)
+	return entry
+}
+
+// writeAudit menyimpan audit log. Kegagalan hanya dicatat ke log server agar perubahan data
+// yang sudah tersimpan tidak dilaporkan gagal ke client.
+func writeAudit(repo repository.AuditRepository, entry *model.AuditLog) {
+	if err := repo.Create(entry); err != nil {
+		log.Printf("Gagal menyimpan audit log %s %s %s: %v", entry.Action, entry.EntityType, entry.EntityID, err)
+	}
+}
+
+// recordAudit mencatat perubahan data oleh user pemilik token ke audit_logs
+func recordAudit(c *fiber.Ctx, db *mongo.Database, action, entityType, entityID string, before, after interface{}) {
+	writeAudit(repository.NewAuditRepository(db), newAuditLog(c, action, entityType, entityID, before, after))
+}
+
+// parseAuditFilter membaca filter audit log dari query string. Tanggal memakai format
+// YYYY-MM-DD dan sampai bersifat inklusif.
+func parseAuditFilter(c *fiber.Ctx) (model.AuditFilter, error) {
+	f := model.AuditFilter{
+		Actor:      strings.TrimSpace(c.Query("actor")),
+		Action:     strings.ToLower(strings.TrimSpace(c.Query("action"))),
+		EntityType: strings.ToLower(strings.TrimSpace(c.Query("entity_type"))),
+		EntityID:   strings.TrimSpace(c.Query("entity_id")),
+	}
+
+	if f.Action != "" && !auditActions[f.Action] {
+		return f, fmt.Errorf("action tidak dikenal: %s", f.Action)
+	}
+	if v := c.Query("dari"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return f, fmt.Errorf("dari harus berformat YYYY-MM-DD")
+		}
+		f.From = &t
+	}
+	if v := c.Query("sampai"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return f, fmt.Errorf("sampai harus berformat YYYY-MM-DD")
+		}
+		end := t.Add(24*time.Hour - time.Nanosecond)
+		f.To = &end
+	}
+	if f.From != nil && f.To != nil && f.From.After(*f.To) {
+		return f, fmt.Errorf("dari tidak boleh setelah sampai")
+	}
+
+	return f, nil
+}
+
+// GetAuditLogsService godoc
+// @Summary Melihat audit log perubahan data
+// @Description Mengembalikan riwayat perubahan data (create, update, soft delete, restore, hard delete, upload, import, rollback) beserta pelaku, diff sebelum/sesudah, dan IP. Terbaru lebih dulu kecuali order=asc.
+// @Tags Audit
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Nomor halaman" default(1)
+// @Param limit query int false "Jumlah data per halaman (maks 100)" default(10)
+// @Param order query string false "Urutan waktu" Enums(asc, desc) default(desc)
+// @Param actor query string false "Username atau ID user pelaku"
+// @Param action query string false "Aksi" Enums(create, update, soft_delete, restore, hard_delete, upload, import, rollback)
+// @Param entity_type query string false "Jenis entitas" Enums(alumni, pekerjaan, file, user, claim_review)
+// @Param entity_id query string false "ID entitas"
+// @Param dari query string false "Tanggal awal (YYYY-MM-DD)"
+// @Param sampai query string false "Tanggal akhir (YYYY-MM-DD)"
+// @Success 200 {object} model.AuditLogResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /api/audit-logs [get]
+func GetAuditLogsService(c *fiber.Ctx, db *mongo.Database) error {
+	meta, _ := parseListQuery(c, map[string]string{"timestamp": "timestamp"}, "timestamp")
+	if c.Query("order") == "" {
+		meta.Order = "desc"
+	}
+
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		return model.NewValidationError(err.Error())
+	}
+
+	logs, err := repository.GetAuditLogs(db, filter, meta.Order, meta.Limit, pageOffset(meta))
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil audit log")
+	}
+
+	total, err := repository.CountAuditLogs(db, filter)
+	if err != nil {
+		return model.WrapError(err, "Gagal menghitung audit log")
+	}
+
+	return c.JSON(model.AuditLogResponse{
+		Message: "Berhasil mengambil audit log",
+		Success: true,
+		Data:    logs,
+		Meta:    withTotal(meta, total),
+	})
+}
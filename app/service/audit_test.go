@@ -0,0 +1,94 @@
+package service
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAuditDiff_Update(t *testing.T) {
+	id := primitive.NewObjectID()
+	before := &model.Alumni{ID: id, Nama: "Budi", Jurusan: "Informatika", Email: "budi@mail.com"}
+	after := &model.Alumni{ID: id, Nama: "Budi", Jurusan: "Informatika", Email: "budi@kampus.ac.id"}
+
+	changes := auditDiff(before, after)
+	if len(changes) != 1 {
+		t.Fatalf("expected only email to change, got %v", changes)
+	}
+	if c := changes["email"]; c.Before != "budi@mail.com" || c.After != "budi@kampus.ac.id" {
+		t.Errorf("unexpected email change: %+v", c)
+	}
+}
+
+func TestAuditDiff_CreateAndDelete(t *testing.T) {
+	user := &model.User{ID: primitive.NewObjectID(), Username: "budi", PasswordHash: "hash"}
+
+	created := auditDiff(nil, user)
+	if created["username"].After != "budi" || created["username"].Before != nil {
+		t.Errorf("create should only have after values, got %v", created)
+	}
+	if _, ok := created["password_hash"]; ok {
+		t.Error("password_hash must not be recorded")
+	}
+	if _, ok := created["_id"]; ok {
+		t.Error("_id must not be recorded")
+	}
+
+	deleted := auditDiff(user, nil)
+	if deleted["username"].Before != "budi" || deleted["username"].After != nil {
+		t.Errorf("delete should only have before values, got %v", deleted)
+	}
+}
+
+func TestAuditDiff_NoChanges(t *testing.T) {
+	if changes := auditDiff(bson.M{"is_deleted": false}, bson.M{"is_deleted": false}); changes != nil {
+		t.Errorf("expected nil, got %v", changes)
+	}
+	if changes := auditDiff((*model.Alumni)(nil), nil); changes != nil {
+		t.Errorf("expected nil for nil pointer, got %v", changes)
+	}
+}
+
+func TestParseAuditFilter(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr bool
+	}{
+		{"", false},
+		{"?action=UPDATE&entity_type=Alumni&dari=2024-01-01&sampai=2024-01-31", false},
+		{"?action=hapus", true},
+		{"?dari=01-01-2024", true},
+		{"?sampai=kemarin", true},
+		{"?dari=2024-02-01&sampai=2024-01-01", true},
+	}
+
+	for _, tt := range tests {
+		app := fiber.New()
+		var got model.AuditFilter
+		var gotErr error
+		app.Get("/", func(c *fiber.Ctx) error {
+			got, gotErr = parseAuditFilter(c)
+			return nil
+		})
+		if _, err := app.Test(httptest.NewRequest("GET", "/"+tt.query, nil)); err != nil {
+			t.Fatal(err)
+		}
+
+		if (gotErr != nil) != tt.wantErr {
+			t.Errorf("%q: error = %v, wantErr %v", tt.query, gotErr, tt.wantErr)
+			continue
+		}
+		if tt.query != "" && !tt.wantErr {
+			if got.Action != model.AuditUpdate || got.EntityType != model.AuditEntityAlumni {
+				t.Errorf("%q: unexpected filter %+v", tt.query, got)
+			}
+			if got.To == nil || got.To.Day() != 31 || got.To.Hour() != 23 {
+				t.Errorf("%q: sampai should cover the whole day, got %v", tt.query, got.To)
+			}
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"github.com/xuri/excelize/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// exportFlushEvery adalah jumlah baris sebelum buffer dikirim ke client
+const exportFlushEvery = 500
+
+// exportContentTypes adalah format export yang didukung beserta content type-nya
+var exportContentTypes = map[string]string{
+	"csv":    "text/csv; charset=utf-8",
+	"xlsx":   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"ndjson": "application/x-ndjson",
+}
+
+var alumniExportColumns = []string{
+	"id", "nim", "nama", "jurusan", "angkatan", "tahun_lulus", "email", "no_telepon", "alamat", "created_at", "updated_at",
+}
+
+var jobExportColumns = []string{
+	"id", "alumni_id", "nama_perusahaan", "posisi_jabatan", "bidang_industri", "lokasi_kerja",
+	"gaji_min", "gaji_max", "mata_uang", "tanggal_mulai_kerja", "tanggal_selesai_kerja",
+	"status_pekerjaan", "deskripsi_pekerjaan", "created_at", "updated_at",
+}
+
+var jobAlumniExportColumns = []string{
+	"alumni_nim", "alumni_nama", "alumni_jurusan", "alumni_angkatan", "alumni_tahun_lulus", "alumni_email",
+}
+
+// exportWriter menulis hasil export baris per baris ke output
+type exportWriter interface {
+	WriteHeader(columns []string) error
+	WriteRow(record interface{}, row []string) error
+	Flush() error
+	Close() error
+}
+
+func newExportWriter(format string, w io.Writer) (exportWriter, error) {
+	switch format {
+	case "csv":
+		return &csvExportWriter{w: csv.NewWriter(w)}, nil
+	case "ndjson":
+		return &ndjsonExportWriter{enc: json.NewEncoder(w)}, nil
+	case "xlsx":
+		f := excelize.NewFile()
+		sw, err := f.NewStreamWriter("Sheet1")
+		if err != nil {
+			return nil, err
+		}
+		return &xlsxExportWriter{file: f, sw: sw, out: w}, nil
+	}
+	return nil, fmt.Errorf("format export %q tidak didukung", format)
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (e *csvExportWriter) WriteHeader(columns []string) error { return e.w.Write(columns) }
+
+func (e *csvExportWriter) WriteRow(_ interface{}, row []string) error { return e.w.Write(row) }
+
+func (e *csvExportWriter) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvExportWriter) Close() error { return e.Flush() }
+
+// ndjsonExportWriter menulis satu objek JSON per baris, header diabaikan
+type ndjsonExportWriter struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonExportWriter) WriteHeader(_ []string) error { return nil }
+
+func (e *ndjsonExportWriter) WriteRow(record interface{}, _ []string) error {
+	return e.enc.Encode(record)
+}
+
+func (e *ndjsonExportWriter) Flush() error { return nil }
+
+func (e *ndjsonExportWriter) Close() error { return nil }
+
+// xlsxExportWriter memakai StreamWriter excelize; file XLSX baru bisa dikirim utuh saat Close
+type xlsxExportWriter struct {
+	file *excelize.File
+	sw   *excelize.StreamWriter
+	out  io.Writer
+	row  int
+}
+
+func (e *xlsxExportWriter) WriteHeader(columns []string) error {
+	return e.WriteRow(nil, columns)
+}
+
+func (e *xlsxExportWriter) WriteRow(_ interface{}, row []string) error {
+	e.row++
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+	values := make([]interface{}, len(row))
+	for i, v := range row {
+		values[i] = v
+	}
+	return e.sw.SetRow(cell, values)
+}
+
+func (e *xlsxExportWriter) Flush() error { return nil }
+
+func (e *xlsxExportWriter) Close() error {
+	defer e.file.Close()
+	if err := e.sw.Flush(); err != nil {
+		return err
+	}
+	return e.file.Write(e.out)
+}
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func alumniExportRow(a model.Alumni) []string {
+	return []string{
+		a.ID.Hex(), a.NIM, a.Nama, a.Jurusan, strconv.Itoa(a.Angkatan), strconv.Itoa(a.TahunLulus),
+		a.Email, stringValue(a.NoTelp), stringValue(a.Alamat),
+		formatExportTime(a.CreatedAt), formatExportTime(a.UpdatedAt),
+	}
+}
+
+func jobExportRow(j model.PekerjaanWithAlumni, withAlumni bool) []string {
+	var gajiMin, gajiMax, mataUang, selesai string
+	if j.GajiRange != nil {
+		gajiMin = strconv.FormatInt(j.GajiRange.Min, 10)
+		gajiMax = strconv.FormatInt(j.GajiRange.Max, 10)
+		mataUang = j.GajiRange.MataUang
+	}
+	if j.TanggalSelesaiKerja != nil {
+		selesai = formatExportTime(*j.TanggalSelesaiKerja)
+	}
+
+	row := []string{
+		j.ID.Hex(), j.AlumniID.Hex(), j.NamaPerusahaan, j.PosisiJabatan, j.BidangIndustri, j.LokasiKerja,
+		gajiMin, gajiMax, mataUang, formatExportTime(j.TanggalMulaiKerja), selesai,
+		j.StatusPekerjaan, j.DeskripsiPekerjaan, formatExportTime(j.CreatedAt), formatExportTime(j.UpdatedAt),
+	}
+	if withAlumni {
+		a := model.Alumni{}
+		if j.Alumni != nil {
+			a = *j.Alumni
+		}
+		row = append(row, a.NIM, a.Nama, a.Jurusan, strconv.Itoa(a.Angkatan), strconv.Itoa(a.TahunLulus), a.Email)
+	}
+	return row
+}
+
+// streamExport menyiapkan header response lalu menjalankan produce di dalam body stream.
+// Error setelah streaming dimulai hanya bisa dicatat di log karena status sudah terkirim.
+func streamExport(c *fiber.Ctx, name, format string, columns []string, produce func(write func(record interface{}, row []string) error) error) error {
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		return model.NewValidationError("Format export harus csv, xlsx, atau ndjson")
+	}
+
+	filename := fmt.Sprintf("%s_%s.%s", name, time.Now().Format("20060102_150405"), format)
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ew, err := newExportWriter(format, w)
+		if err != nil {
+			log.Printf("Export %s gagal: %v", name, err)
+			return
+		}
+
+		if err := ew.WriteHeader(columns); err != nil {
+			log.Printf("Export %s gagal: %v", name, err)
+			return
+		}
+
+		count := 0
+		err = produce(func(record interface{}, row []string) error {
+			if err := ew.WriteRow(record, row); err != nil {
+				return err
+			}
+			count++
+			if count%exportFlushEvery == 0 {
+				if err := ew.Flush(); err != nil {
+					return err
+				}
+				return w.Flush()
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("Export %s terhenti setelah %d baris: %v", name, count, err)
+		}
+
+		if err := ew.Close(); err != nil {
+			log.Printf("Export %s gagal ditutup: %v", name, err)
+		}
+		w.Flush()
+	})
+
+	return nil
+}
+
+// ExportAlumniService godoc
+// @Summary Export data alumni
+// @Description Mengunduh data alumni dalam format CSV, XLSX, atau NDJSON. Menerima pencarian dan sorting yang sama dengan listing alumni.
+// @Tags Alumni
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/x-ndjson
+// @Security BearerAuth
+// @Param format query string false "Format file" Enums(csv, xlsx, ndjson) default(csv)
+// @Param sortBy query string false "Field sorting" Enums(id, nama, nim, jurusan, angkatan, tahun_lulus, created_at) default(id)
+// @Param order query string false "Urutan sorting" Enums(asc, desc) default(asc)
+// @Param search query string false "Kata kunci pencarian nama, nim, atau jurusan"
+// @Success 200 {file} file
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Router /unair/alumni/export [get]
+func ExportAlumniService(c *fiber.Ctx, db *mongo.Database) error {
+	meta, sortField := parseListQuery(c, alumniSortFields, "id")
+	format := c.Query("format", "csv")
+
+	return streamExport(c, "alumni", format, alumniExportColumns, func(write func(interface{}, []string) error) error {
+		return repository.StreamAlumni(db, meta.Search, sortField, meta.Order, func(a model.Alumni) error {
+			return write(a, alumniExportRow(a))
+		})
+	})
+}
+
+// ExportJobService godoc
+// @Summary Export data pekerjaan alumni
+// @Description Mengunduh data pekerjaan dalam format CSV, XLSX, atau NDJSON. Menerima filter dan sorting yang sama dengan listing pekerjaan. Dengan join=alumni setiap baris pekerjaan dilengkapi kolom data alumninya.
+// @Tags PekerjaanAlumni
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/x-ndjson
+// @Security BearerAuth
+// @Param format query string false "Format file" Enums(csv, xlsx, ndjson) default(csv)
+// @Param join query string false "Gabungkan data alumni" Enums(alumni)
+// @Param sortBy query string false "Field sorting" Enums(id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, tanggal_mulai_kerja, created_at) default(id)
+// @Param order query string false "Urutan sorting" Enums(asc, desc) default(asc)
+// @Param search query string false "Kata kunci nama perusahaan atau posisi jabatan"
+// @Param bidang_industri query string false "Filter bidang industri"
+// @Param lokasi_kerja query string false "Filter lokasi kerja"
+// @Param status_pekerjaan query string false "Filter status pekerjaan"
+// @Param mulai_dari query string false "Tanggal mulai kerja paling awal (YYYY-MM-DD)"
+// @Param mulai_sampai query string false "Tanggal mulai kerja paling akhir (YYYY-MM-DD)"
+// @Success 200 {file} file
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Router /unair/pekerjaan/export [get]
+func ExportJobService(c *fiber.Ctx, db *mongo.Database) error {
+	meta, sortField := parseListQuery(c, jobSortFields, "id")
+	filter, err := parseJobFilter(c, meta.Search)
+	if err != nil {
+		return model.NewValidationError(err.Error())
+	}
+
+	withAlumni := c.Query("join") == "alumni"
+	columns := jobExportColumns
+	if withAlumni {
+		columns = append(append([]string{}, jobExportColumns...), jobAlumniExportColumns...)
+	}
+
+	return streamExport(c, "pekerjaan", c.Query("format", "csv"), columns, func(write func(interface{}, []string) error) error {
+		return repository.StreamJobs(db, filter, sortField, meta.Order, withAlumni, func(j model.PekerjaanWithAlumni) error {
+			return write(j, jobExportRow(j, withAlumni))
+		})
+	})
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+)
+
+func writeExport(t *testing.T, format string, alumni []model.Alumni) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	ew, err := newExportWriter(format, &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ew.WriteHeader(alumniExportColumns); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, a := range alumni {
+		if err := ew.WriteRow(a, alumniExportRow(a)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := ew.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return &buf
+}
+
+var exportSample = []model.Alumni{
+	{NIM: "187221001", Nama: "Budi", Jurusan: "Informatika", Angkatan: 2018, TahunLulus: 2022},
+	{NIM: "187221002", Nama: "Sari, S.Kom", Jurusan: "Informatika", Angkatan: 2018, TahunLulus: 2022},
+}
+
+func TestExportAlumni_CSV(t *testing.T) {
+	buf := writeExport(t, "csv", exportSample)
+
+	// hasil export harus bisa dibaca kembali oleh import
+	rows, err := ReadAlumniCSV(buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 || rows[1].Values["nama"] != "Sari, S.Kom" {
+		t.Errorf("unexpected rows: %+v", rows)
+	}
+}
+
+func TestExportAlumni_XLSX(t *testing.T) {
+	buf := writeExport(t, "xlsx", exportSample)
+
+	rows, err := ReadAlumniXLSX(buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Values["nim"] != "187221001" {
+		t.Errorf("unexpected rows: %+v", rows)
+	}
+}
+
+func TestExportAlumni_NDJSON(t *testing.T) {
+	buf := writeExport(t, "ndjson", exportSample)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	if !strings.Contains(lines[0], `"nim":"187221001"`) {
+		t.Errorf("unexpected first line: %s", lines[0])
+	}
+}
+
+func TestJobExportRow_WithAlumni(t *testing.T) {
+	job := model.PekerjaanWithAlumni{
+		PekerjaanAlumni: model.PekerjaanAlumni{
+			NamaPerusahaan: "Google",
+			GajiRange:      &model.GajiRange{Min: 5000000, Max: 10000000, MataUang: "IDR"},
+		},
+		Alumni: &model.Alumni{NIM: "187221001", Nama: "Budi"},
+	}
+
+	row := jobExportRow(job, true)
+	if len(row) != len(jobExportColumns)+len(jobAlumniExportColumns) {
+		t.Fatalf("row has %d columns", len(row))
+	}
+	if row[6] != "5000000" || row[7] != "10000000" || row[len(jobExportColumns)] != "187221001" {
+		t.Errorf("unexpected row: %v", row)
+	}
+}
@@ -15,6 +15,7 @@ import (
 type FileService interface {
 	UploadFile(c *fiber.Ctx) error
 	GetAllFiles(c *fiber.Ctx) error
+	GetMyFiles(c *fiber.Ctx) error
 	GetFileByID(c *fiber.Ctx) error
 	DeleteFile(c *fiber.Ctx) error
 	UploadPhoto(c *fiber.Ctx) error
@@ -23,12 +24,14 @@ type FileService interface {
 
 type fileService struct {
 	repo       repository.FileRepository
+	audit      repository.AuditRepository
 	uploadPath string
 }
 
-func NewFileService(repo repository.FileRepository, uploadPath string) FileService {
+func NewFileService(repo repository.FileRepository, audit repository.AuditRepository, uploadPath string) FileService {
 	return &fileService{
 		repo:       repo,
+		audit:      audit,
 		uploadPath: uploadPath,
 	}
 }
@@ -41,24 +44,17 @@ func NewFileService(repo repository.FileRepository, uploadPath string) FileServi
 // @Produce json
 // @Param file formData file true "File yang akan diunggah"
 // @Success 201 {object} model.FileResponse "Berhasil mengunggah file"
-// @Failure 400 {object} map[string]interface{} "File tidak valid"
-// @Failure 500 {object} map[string]interface{} "Kesalahan server"
+// @Failure 400 {object} model.ErrorResponse "File tidak valid"
+// @Failure 500 {object} model.ErrorResponse "Kesalahan server"
 // @Router /api/files/upload [post]
 func (s *fileService) UploadFile(c *fiber.Ctx) error {
 	fileHeader, err := c.FormFile("file")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "No file uploaded",
-			"error":   err.Error(),
-		})
+		return model.NewValidationError("No file uploaded")
 	}
 
 	if fileHeader.Size > 10*1024*1024 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "File size exceeds 10MB",
-		})
+		return model.NewValidationError("File size exceeds 10MB")
 	}
 
 	allowedTypes := map[string]bool{
@@ -70,10 +66,7 @@ func (s *fileService) UploadFile(c *fiber.Ctx) error {
 
 	contentType := fileHeader.Header.Get("Content-Type")
 	if !allowedTypes[contentType] {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "File type not allowed",
-		})
+		return model.NewValidationError("File type not allowed")
 	}
 
 	ext := filepath.Ext(fileHeader.Filename)
@@ -81,49 +74,29 @@ func (s *fileService) UploadFile(c *fiber.Ctx) error {
 	filePath := filepath.Join(s.uploadPath, newFileName)
 
 	if err := os.MkdirAll(s.uploadPath, os.ModePerm); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to create upload directory",
-			"error":   err.Error(),
-		})
+		return model.WrapError(err, "Failed to create upload directory")
 	}
 
 	file, err := fileHeader.Open()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to open file",
-			"error":   err.Error(),
-		})
+		return model.WrapError(err, "Failed to open file")
 	}
 	defer file.Close()
 
 	out, err := os.Create(filePath)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to save file",
-			"error":   err.Error(),
-		})
+		return model.WrapError(err, "Failed to save file")
 	}
 	defer out.Close()
 
 	if _, err := out.ReadFrom(file); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to write file",
-			"error":   err.Error(),
-		})
+		return model.WrapError(err, "Failed to write file")
 	}
 
 	userID := c.Locals("user_id").(string)
 	userObjectID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Invalid user ID format",
-			"error":   err.Error(),
-		})
+		return model.NewValidationError("Invalid user ID format")
 	}
 
 	fileModel := &model.File{
@@ -137,13 +110,11 @@ func (s *fileService) UploadFile(c *fiber.Ctx) error {
 
 	if err := s.repo.Create(fileModel); err != nil {
 		os.Remove(filePath)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to save file metadata",
-			"error":   err.Error(),
-		})
+		return model.WrapError(err, "Failed to save file metadata")
 	}
 
+	writeAudit(s.audit, newAuditLog(c, model.AuditUpload, model.AuditEntityFile, fileModel.ID.Hex(), nil, fileModel))
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"success": true,
 		"message": "File uploaded successfully",
@@ -153,20 +124,26 @@ func (s *fileService) UploadFile(c *fiber.Ctx) error {
 
 // GetAllFiles godoc
 // @Summary Mendapatkan semua file yang diunggah
-// @Description Mengambil daftar semua file beserta metadata-nya
+// @Description Mengambil daftar file beserta metadata-nya. Dengan permission file:read:own hanya file milik sendiri yang dikembalikan, dengan file:read:jurusan hanya file alumni pada jurusan user.
 // @Tags File
 // @Produce json
 // @Success 200 {array} model.FileResponse "Daftar file berhasil diambil"
-// @Failure 500 {object} map[string]interface{} "Kesalahan server"
+// @Failure 500 {object} model.ErrorResponse "Kesalahan server"
 // @Router /api/files [get]
 func (s *fileService) GetAllFiles(c *fiber.Ctx) error {
-	files, err := s.repo.FindAll()
+	var files []model.File
+	var err error
+	switch permissionScope(c) {
+	case model.ScopeAll:
+		files, err = s.repo.FindAll()
+	case model.ScopeJurusan:
+		files, err = s.repo.FindByJurusan(jurusanScope(c))
+	default:
+		userID, _ := c.Locals("user_id").(primitive.ObjectID)
+		files, err = s.repo.FindByUserID(userID)
+	}
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to get files",
-			"error":   err.Error(),
-		})
+		return model.WrapError(err, "Failed to get files")
 	}
 
 	var responses []model.FileResponse
@@ -181,26 +158,63 @@ func (s *fileService) GetAllFiles(c *fiber.Ctx) error {
 	})
 }
 
+// GetMyFiles godoc
+// @Summary Mendapatkan file milik user yang login
+// @Description Mengambil daftar foto dan sertifikat yang diunggah oleh user pemilik token, terbaru lebih dulu
+// @Tags File
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.FileResponse "Daftar file berhasil diambil"
+// @Failure 401 {object} model.ErrorResponse "Token tidak valid"
+// @Failure 500 {object} model.ErrorResponse "Kesalahan server"
+// @Router /api/me/files [get]
+func (s *fileService) GetMyFiles(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(primitive.ObjectID)
+	if !ok {
+		return model.NewUnauthorizedError("User tidak dikenali")
+	}
+
+	files, err := s.repo.FindByUserID(userID)
+	if err != nil {
+		return model.WrapError(err, "Failed to get files")
+	}
+
+	responses := make([]model.FileResponse, 0, len(files))
+	for _, file := range files {
+		responses = append(responses, *s.toFileResponse(&file))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Files retrieved successfully",
+		"data":    responses,
+	})
+}
+
 // GetFileByID godoc
 // @Summary Mendapatkan file berdasarkan ID
-// @Description Mengambil metadata dan informasi file sesuai ID
+// @Description Mengambil metadata dan informasi file sesuai ID. Dengan permission file:read:own hanya file milik sendiri yang bisa diambil, dengan file:read:jurusan hanya file alumni pada jurusan user.
 // @Tags File
 // @Produce json
 // @Param id path string true "ID File"
 // @Success 200 {object} model.FileResponse "Berhasil mengambil file"
-// @Failure 404 {object} map[string]interface{} "File tidak ditemukan"
-// @Failure 500 {object} map[string]interface{} "Kesalahan server"
+// @Failure 404 {object} model.ErrorResponse "File tidak ditemukan"
+// @Failure 500 {object} model.ErrorResponse "Kesalahan server"
 // @Router /api/files/{id} [get]
 func (s *fileService) GetFileByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 
 	file, err := s.repo.FindByID(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "File not found",
-			"error":   err.Error(),
-		})
+		return model.WrapError(err, "Failed to retrieve file")
+	}
+
+	allowed, err := s.canAccess(c, file)
+	if err != nil {
+		return model.WrapError(err, "Failed to check file access")
+	}
+	if !allowed {
+		return repository.ErrFileNotFound
 	}
 
 	return c.JSON(fiber.Map{
@@ -212,24 +226,28 @@ func (s *fileService) GetFileByID(c *fiber.Ctx) error {
 
 // DeleteFile godoc
 // @Summary Menghapus file
-// @Description Menghapus file dari penyimpanan dan metadata dari database
+// @Description Menghapus file dari penyimpanan dan metadata dari database. Dengan permission file:delete:own hanya file milik sendiri yang bisa dihapus.
 // @Tags File
 // @Param id path string true "ID File"
 // @Produce json
 // @Success 200 {object} map[string]interface{} "Berhasil menghapus file"
-// @Failure 404 {object} map[string]interface{} "File tidak ditemukan"
-// @Failure 500 {object} map[string]interface{} "Kesalahan server"
+// @Failure 404 {object} model.ErrorResponse "File tidak ditemukan"
+// @Failure 500 {object} model.ErrorResponse "Kesalahan server"
 // @Router /api/files/{id} [delete]
 func (s *fileService) DeleteFile(c *fiber.Ctx) error {
 	id := c.Params("id")
 
 	file, err := s.repo.FindByID(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "File not found",
-			"error":   err.Error(),
-		})
+		return model.WrapError(err, "Failed to retrieve file")
+	}
+
+	allowed, err := s.canAccess(c, file)
+	if err != nil {
+		return model.WrapError(err, "Failed to check file access")
+	}
+	if !allowed {
+		return repository.ErrFileNotFound
 	}
 
 	if err := os.Remove(file.FilePath); err != nil {
@@ -237,13 +255,11 @@ func (s *fileService) DeleteFile(c *fiber.Ctx) error {
 	}
 
 	if err := s.repo.Delete(id); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to delete file",
-			"error":   err.Error(),
-		})
+		return model.WrapError(err, "Failed to delete file")
 	}
 
+	writeAudit(s.audit, newAuditLog(c, model.AuditHardDelete, model.AuditEntityFile, id, file, nil))
+
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "File deleted successfully",
@@ -259,8 +275,8 @@ func (s *fileService) DeleteFile(c *fiber.Ctx) error {
 // @Param user_id path string true "ID User"
 // @Param file formData file true "Foto yang akan diunggah"
 // @Success 201 {object} model.FileResponse "Berhasil mengunggah foto"
-// @Failure 400 {object} map[string]interface{} "Kesalahan input"
-// @Failure 500 {object} map[string]interface{} "Kesalahan server"
+// @Failure 400 {object} model.ErrorResponse "Kesalahan input"
+// @Failure 500 {object} model.ErrorResponse "Kesalahan server"
 // @Router /api/files/upload/photo/{user_id} [post]
 func (s *fileService) UploadPhoto(c *fiber.Ctx) error {
 	return s.uploadWithValidation(c, map[string]bool{
@@ -279,8 +295,8 @@ func (s *fileService) UploadPhoto(c *fiber.Ctx) error {
 // @Param user_id path string true "ID User"
 // @Param file formData file true "Sertifikat yang akan diunggah"
 // @Success 201 {object} model.FileResponse "Berhasil mengunggah sertifikat"
-// @Failure 400 {object} map[string]interface{} "Kesalahan input"
-// @Failure 500 {object} map[string]interface{} "Kesalahan server"
+// @Failure 400 {object} model.ErrorResponse "Kesalahan input"
+// @Failure 500 {object} model.ErrorResponse "Kesalahan server"
 // @Router /api/files/upload/certificate/{user_id} [post]
 func (s *fileService) UploadCertificate(c *fiber.Ctx) error {
 	return s.uploadWithValidation(c, map[string]bool{
@@ -292,26 +308,16 @@ func (s *fileService) UploadCertificate(c *fiber.Ctx) error {
 func (s *fileService) uploadWithValidation(c *fiber.Ctx, allowedTypes map[string]bool, maxSize int64) error {
 	fileHeader, err := c.FormFile("file")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "No file uploaded",
-			"error":   err.Error(),
-		})
+		return model.NewValidationError("No file uploaded")
 	}
 
 	if fileHeader.Size > maxSize {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": fmt.Sprintf("File size exceeds limit (%.2f MB)", float64(maxSize)/1024/1024),
-		})
+		return model.NewValidationError(fmt.Sprintf("File size exceeds limit (%.2f MB)", float64(maxSize)/1024/1024))
 	}
 
 	contentType := fileHeader.Header.Get("Content-Type")
 	if !allowedTypes[contentType] {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Invalid file type",
-		})
+		return model.NewValidationError("Invalid file type")
 	}
 
 	ext := filepath.Ext(fileHeader.Filename)
@@ -319,49 +325,29 @@ func (s *fileService) uploadWithValidation(c *fiber.Ctx, allowedTypes map[string
 	filePath := filepath.Join(s.uploadPath, newFileName)
 
 	if err := os.MkdirAll(s.uploadPath, os.ModePerm); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to create upload directory",
-			"error":   err.Error(),
-		})
+		return model.WrapError(err, "Failed to create upload directory")
 	}
 
 	file, err := fileHeader.Open()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to open file",
-			"error":   err.Error(),
-		})
+		return model.WrapError(err, "Failed to open file")
 	}
 	defer file.Close()
 
 	out, err := os.Create(filePath)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to save file",
-			"error":   err.Error(),
-		})
+		return model.WrapError(err, "Failed to save file")
 	}
 	defer out.Close()
 
 	if _, err := out.ReadFrom(file); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to write file",
-			"error":   err.Error(),
-		})
+		return model.WrapError(err, "Failed to write file")
 	}
 
 	userIDParam := c.Params("user_id")
 	userObjectID, err := primitive.ObjectIDFromHex(userIDParam)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Invalid user ID format",
-			"error":   err.Error(),
-		})
+		return model.NewValidationError("Invalid user ID format")
 	}
 
 	fileModel := &model.File{
@@ -375,13 +361,11 @@ func (s *fileService) uploadWithValidation(c *fiber.Ctx, allowedTypes map[string
 
 	if err := s.repo.Create(fileModel); err != nil {
 		os.Remove(filePath)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to save file metadata",
-			"error":   err.Error(),
-		})
+		return model.WrapError(err, "Failed to save file metadata")
 	}
 
+	writeAudit(s.audit, newAuditLog(c, model.AuditUpload, model.AuditEntityFile, fileModel.ID.Hex(), nil, fileModel))
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"success": true,
 		"message": "File uploaded successfully",
@@ -389,6 +373,18 @@ func (s *fileService) uploadWithValidation(c *fiber.Ctx, allowedTypes map[string
 	})
 }
 
+// canAccess memeriksa apakah user pemilik token boleh mengakses file sesuai cakupan permission
+func (s *fileService) canAccess(c *fiber.Ctx, file *model.File) (bool, error) {
+	switch permissionScope(c) {
+	case model.ScopeAll:
+		return true, nil
+	case model.ScopeJurusan:
+		return s.repo.OwnerInJurusan(file.UserID, jurusanScope(c))
+	}
+	userID, ok := c.Locals("user_id").(primitive.ObjectID)
+	return ok && !userID.IsZero() && userID == file.UserID, nil
+}
+
 func (s *fileService) toFileResponse(file *model.File) *model.FileResponse {
 	return &model.FileResponse{
 		ID:           file.ID,
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"github.com/noorfarihaf11/clean-arc/middleware"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// stubFileRepository mengembalikan file atau error yang sudah ditentukan untuk FindByID
+type stubFileRepository struct {
+	repository.FileRepository
+	file *model.File
+	err  error
+}
+
+func (r stubFileRepository) FindByID(id string) (*model.File, error) {
+	return r.file, r.err
+}
+
+func TestFileService_ErrorMapping(t *testing.T) {
+	owner := primitive.NewObjectID()
+	driverErr := mongo.CommandError{Code: 6, Message: "connection reset by peer 10.0.0.5:27017"}
+
+	tests := []struct {
+		name    string
+		repo    stubFileRepository
+		status  int
+		message string
+	}{
+		{"file tidak ada", stubFileRepository{err: repository.ErrFileNotFound}, 404, "File not found"},
+		{"file milik user lain", stubFileRepository{file: &model.File{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID()}}, 404, "File not found"},
+		{"error driver", stubFileRepository{err: driverErr}, 500, "Failed to retrieve file"},
+	}
+
+	for _, tt := range tests {
+		for _, method := range []string{"GET", "DELETE"} {
+			t.Run(method+" "+tt.name, func(t *testing.T) {
+				svc := NewFileService(tt.repo, nil, t.TempDir())
+				app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+				app.Use(func(c *fiber.Ctx) error {
+					c.Locals("user_id", owner)
+					c.Locals("permission_scope", model.ScopeOwn)
+					return c.Next()
+				})
+				app.Get("/:id", svc.GetFileByID)
+				app.Delete("/:id", svc.DeleteFile)
+
+				resp, err := app.Test(httptest.NewRequest(method, "/"+primitive.NewObjectID().Hex(), nil))
+				if err != nil {
+					t.Fatal(err)
+				}
+				raw, _ := io.ReadAll(resp.Body)
+				var body model.ErrorResponse
+				if err := json.Unmarshal(raw, &body); err != nil {
+					t.Fatal(err)
+				}
+
+				if resp.StatusCode != tt.status || body.Message != tt.message {
+					t.Errorf("got %d %q, want %d %q", resp.StatusCode, body.Message, tt.status, tt.message)
+				}
+				if strings.Contains(string(raw), "connection reset") {
+					t.Errorf("pesan error driver bocor ke client: %s", raw)
+				}
+			})
+		}
+	}
+}
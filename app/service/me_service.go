@@ -0,0 +1,205 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// myProfileFields adalah field profil yang boleh diubah sendiri oleh alumni
+var myProfileFields = map[string]bool{"email": true, "no_telepon": true, "alamat": true}
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9][0-9 -]{6,18}[0-9]$`)
+
+const maxAlamatLength = 500
+
+// currentAlumni mengambil profil alumni milik user pemilik token, ErrAlumniNotFound jika belum ada
+func currentAlumni(c *fiber.Ctx, db *mongo.Database) (*model.Alumni, error) {
+	userID, ok := c.Locals("user_id").(primitive.ObjectID)
+	if !ok {
+		return nil, repository.ErrAlumniNotFound
+	}
+	return repository.GetAlumniByUserID(db, userID)
+}
+
+// requireCurrentAlumni seperti currentAlumni tetapi akun tanpa profil alumni menjadi error
+// not found dengan pesan yang menjelaskannya. Handler berhenti dan mengembalikan error jika
+// alumni yang dikembalikan nil.
+func requireCurrentAlumni(c *fiber.Ctx, db *mongo.Database) (*model.Alumni, error) {
+	alumni, err := currentAlumni(c, db)
+	if errors.Is(err, repository.ErrAlumniNotFound) {
+		return nil, model.NewNotFoundError("Akun ini belum memiliki profil alumni")
+	}
+	if err != nil {
+		return nil, model.WrapError(err, "Gagal mengambil profil alumni")
+	}
+	return alumni, nil
+}
+
+// ValidateMyProfileUpdate memeriksa body update profil: hanya email, no_telepon, dan alamat
+// yang diterima. Mengembalikan field yang akan di-$set.
+func ValidateMyProfileUpdate(body []byte) (bson.M, []model.FieldError) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, []model.FieldError{{Field: "body", Message: "body harus berupa objek JSON"}}
+	}
+
+	var errs []model.FieldError
+	var forbidden []string
+	for k := range raw {
+		if !myProfileFields[k] {
+			forbidden = append(forbidden, k)
+		}
+	}
+	if len(forbidden) > 0 {
+		sort.Strings(forbidden)
+		errs = append(errs, model.FieldError{Field: strings.Join(forbidden, ","), Message: "field tidak boleh diubah: " + strings.Join(forbidden, ", ")})
+	}
+
+	var req model.UpdateMyProfileRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, append(errs, model.FieldError{Field: "body", Message: "format field tidak valid: " + err.Error()})
+	}
+
+	set := bson.M{}
+	if req.Email != nil {
+		email := strings.ToLower(strings.TrimSpace(*req.Email))
+		if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+			errs = append(errs, model.FieldError{Field: "email", Message: "email tidak valid"})
+		}
+		set["email"] = email
+	}
+	if req.NoTelp != nil {
+		phone := strings.TrimSpace(*req.NoTelp)
+		switch {
+		case phone == "":
+			set["no_telepon"] = nil
+		case !phonePattern.MatchString(phone):
+			errs = append(errs, model.FieldError{Field: "no_telepon", Message: "no_telepon harus 8-20 digit angka"})
+		default:
+			set["no_telepon"] = phone
+		}
+	}
+	if req.Alamat != nil {
+		alamat := strings.TrimSpace(*req.Alamat)
+		switch {
+		case alamat == "":
+			set["alamat"] = nil
+		case len(alamat) > maxAlamatLength:
+			errs = append(errs, model.FieldError{Field: "alamat", Message: "alamat maksimal 500 karakter"})
+		default:
+			set["alamat"] = alamat
+		}
+	}
+
+	if len(errs) == 0 && len(set) == 0 {
+		errs = append(errs, model.FieldError{Field: "body", Message: "tidak ada field yang diubah"})
+	}
+	return set, errs
+}
+
+// GetMyProfileService godoc
+// @Summary Profil alumni milik user yang login
+// @Description Mengambil data alumni yang tertaut ke akun pemilik token
+// @Tags Me
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.SingleAlumniResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /api/me [get]
+func GetMyProfileService(c *fiber.Ctx, db *mongo.Database) error {
+	alumni, err := requireCurrentAlumni(c, db)
+	if alumni == nil {
+		return err
+	}
+
+	return c.JSON(model.SingleAlumniResponse{
+		Message: "Berhasil mengambil profil",
+		Success: true,
+		Alumni:  *alumni,
+	})
+}
+
+// UpdateMyProfileService godoc
+// @Summary Mengubah profil alumni sendiri
+// @Description Alumni hanya dapat mengubah email, no_telepon, dan alamat. Field lain (nim, nama, jurusan, angkatan, tahun_lulus) hanya bisa diubah admin; request yang memuatnya ditolak.
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.UpdateMyProfileRequest true "Field yang diubah"
+// @Success 200 {object} model.SingleAlumniResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /api/me [put]
+func UpdateMyProfileService(c *fiber.Ctx, db *mongo.Database) error {
+	alumni, err := requireCurrentAlumni(c, db)
+	if alumni == nil {
+		return err
+	}
+
+	fields, errs := ValidateMyProfileUpdate(c.Body())
+	if len(errs) > 0 {
+		return model.NewValidationError("Data profil tidak valid", errs...)
+	}
+
+	updated, err := repository.UpdateAlumniFields(db, alumni.ID, fields)
+	if err != nil {
+		return model.WrapError(err, "Gagal memperbarui profil")
+	}
+
+	recordAudit(c, db, model.AuditUpdate, model.AuditEntityAlumni, updated.ID.Hex(), alumni, updated)
+	recordAlumniRevision(c, db, model.AuditUpdate, alumni, updated)
+
+	return c.JSON(model.SingleAlumniResponse{
+		Message: "Profil berhasil diperbarui",
+		Success: true,
+		Alumni:  *updated,
+	})
+}
+
+// GetMyJobsService godoc
+// @Summary Riwayat pekerjaan milik user yang login
+// @Description Mengambil semua pekerjaan (yang tidak dihapus) dari profil alumni pemilik token
+// @Tags Me
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.PekerjaanAlumni
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /api/me/pekerjaan [get]
+func GetMyJobsService(c *fiber.Ctx, db *mongo.Database) error {
+	alumni, err := requireCurrentAlumni(c, db)
+	if alumni == nil {
+		return err
+	}
+
+	jobs, err := repository.GetJobsByAlumniID(db, alumni.ID.Hex())
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil data pekerjaan")
+	}
+	if jobs == nil {
+		jobs = []model.PekerjaanAlumni{}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Berhasil mengambil data pekerjaan",
+		"data":    jobs,
+	})
+}
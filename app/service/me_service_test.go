@@ -0,0 +1,43 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateMyProfileUpdate(t *testing.T) {
+	fields, errs := ValidateMyProfileUpdate([]byte(`{"email":" Budi@Mail.com ","no_telepon":"0812-3456-7890","alamat":""}`))
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if fields["email"] != "budi@mail.com" || fields["no_telepon"] != "0812-3456-7890" {
+		t.Errorf("unexpected fields: %v", fields)
+	}
+	if v, ok := fields["alamat"]; !ok || v != nil {
+		t.Errorf("empty alamat should be cleared, got %v", fields)
+	}
+}
+
+func TestValidateMyProfileUpdate_RejectsProtectedFields(t *testing.T) {
+	_, errs := ValidateMyProfileUpdate([]byte(`{"nim":"187221999","nama":"Bukan Budi","email":"budi@mail.com"}`))
+	if len(errs) != 1 || !strings.Contains(errs[0].Message, "nama, nim") {
+		t.Errorf("expected protected fields to be rejected, got %v", errs)
+	}
+}
+
+func TestValidateMyProfileUpdate_Invalid(t *testing.T) {
+	tests := []string{
+		`[]`,
+		`{}`,
+		`{"email":"bukan-email"}`,
+		`{"no_telepon":"abc"}`,
+		`{"alamat":"` + strings.Repeat("a", 501) + `"}`,
+		`{"email":123}`,
+	}
+
+	for _, body := range tests {
+		if _, errs := ValidateMyProfileUpdate([]byte(body)); len(errs) == 0 {
+			t.Errorf("expected error for %s", body)
+		}
+	}
+}
@@ -2,9 +2,11 @@ package service
 
 import (
 	"fmt"
-	"log"
 	"strings"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,12 +15,65 @@ import (
 	"github.com/noorfarihaf11/clean-arc/utils"
 )
 
+// jobSortFields adalah daftar field yang boleh dipakai untuk sorting pekerjaan
+var jobSortFields = map[string]string{
+	"id":                  "_id",
+	"nama_perusahaan":     "nama_perusahaan",
+	"posisi_jabatan":      "posisi_jabatan",
+	"bidang_industri":     "bidang_industri",
+	"lokasi_kerja":        "lokasi_kerja",
+	"tanggal_mulai_kerja": "tanggal_mulai_kerja",
+	"created_at":          "created_at",
+}
+
+// parseJobFilter membaca filter listing pekerjaan dari query string.
+// Tanggal memakai format YYYY-MM-DD dan mulai_sampai bersifat inklusif.
+func parseJobFilter(c *fiber.Ctx, search string) (model.JobFilter, error) {
+	f := model.JobFilter{
+		Search:          search,
+		BidangIndustri:  strings.TrimSpace(c.Query("bidang_industri")),
+		LokasiKerja:     strings.TrimSpace(c.Query("lokasi_kerja")),
+		StatusPekerjaan: strings.TrimSpace(c.Query("status_pekerjaan")),
+	}
+
+	if v := c.Query("mulai_dari"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return f, fmt.Errorf("mulai_dari harus berformat YYYY-MM-DD")
+		}
+		f.MulaiDari = &t
+	}
+	if v := c.Query("mulai_sampai"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return f, fmt.Errorf("mulai_sampai harus berformat YYYY-MM-DD")
+		}
+		end := t.Add(24*time.Hour - time.Nanosecond)
+		f.MulaiSampai = &end
+	}
+	if f.MulaiDari != nil && f.MulaiSampai != nil && f.MulaiDari.After(*f.MulaiSampai) {
+		return f, fmt.Errorf("mulai_dari tidak boleh setelah mulai_sampai")
+	}
+
+	return f, nil
+}
+
 // GetAllJobService godoc
 // @Summary Mendapatkan semua data pekerjaan
-// @Description Mengembalikan daftar semua pekerjaan alumni yang tersedia
+// @Description Mengembalikan daftar pekerjaan alumni dengan pagination, filter, dan sorting. Staff hanya melihat pekerjaan alumni pada jurusannya.
 // @Tags PekerjaanAlumni
 // @Accept json
 // @Produce json
+// @Param page query int false "Nomor halaman" default(1)
+// @Param limit query int false "Jumlah data per halaman (maks 100)" default(10)
+// @Param sortBy query string false "Field sorting" Enums(id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, tanggal_mulai_kerja, created_at) default(id)
+// @Param order query string false "Urutan sorting" Enums(asc, desc) default(asc)
+// @Param search query string false "Kata kunci nama perusahaan atau posisi jabatan"
+// @Param bidang_industri query string false "Filter bidang industri"
+// @Param lokasi_kerja query string false "Filter lokasi kerja"
+// @Param status_pekerjaan query string false "Filter status pekerjaan"
+// @Param mulai_dari query string false "Tanggal mulai kerja paling awal (YYYY-MM-DD)"
+// @Param mulai_sampai query string false "Tanggal mulai kerja paling akhir (YYYY-MM-DD)"
 // @Success 200 {object} model.PekerjaanAlumniResponse "Berhasil mengambil data pekerjaan alumni"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
@@ -27,26 +82,45 @@ import (
 func GetAllJobService(c *fiber.Ctx, db *mongo.Database) error {
 	token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
 	if token == "" {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Unauthorized"})
+		return model.NewUnauthorizedError("Unauthorized")
 	}
 	if _, err := utils.ValidateToken(token); err != nil {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+		return model.NewUnauthorizedError("Token tidak valid")
 	}
 
-	jobs, err := repository.GetAllJobs(db)
+	meta, sortField := parseListQuery(c, jobSortFields, "id")
+	filter, err := parseJobFilter(c, meta.Search)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+		return model.NewValidationError(err.Error())
 	}
-	if len(jobs) == 0 {
-		return c.Status(404).JSON(fiber.Map{"success": false, "message": "Data kosong"})
+
+	scope, err := requireJobScope(c, db)
+	if scope == nil {
+		return err
 	}
+	filter.Scope = scope
 
-	return c.JSON(fiber.Map{"success": true, "data": jobs})
+	jobs, err := repository.GetJobsRepo(db, filter, sortField, meta.Order, meta.Limit, pageOffset(meta))
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil data pekerjaan")
+	}
+
+	total, err := repository.CountJobsRepo(db, filter)
+	if err != nil {
+		return model.WrapError(err, "Gagal menghitung data pekerjaan")
+	}
+
+	return c.JSON(model.PekerjaanAlumniResponse{
+		Message: "Berhasil mengambil data pekerjaan",
+		Success: true,
+		Data:    jobs,
+		Meta:    withTotal(meta, total),
+	})
 }
 
 // GetJobByIDService godoc
 // @Summary Mendapatkan pekerjaan berdasarkan ID
-// @Description Mengambil data pekerjaan sesuai ID pekerjaan
+// @Description Mengambil data pekerjaan sesuai ID pekerjaan. Pekerjaan di luar cakupan user dianggap tidak ditemukan.
 // @Tags PekerjaanAlumni
 // @Accept json
 // @Produce json
@@ -54,23 +128,29 @@ func GetAllJobService(c *fiber.Ctx, db *mongo.Database) error {
 // @Success 200 {object} model.SinglePekerjaanResponse "Berhasil mengambil data pekerjaan alumni"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /unair/pekerjaan/{id} [get]
 func GetJobByIDService(c *fiber.Ctx, db *mongo.Database) error {
 	token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
 	if token == "" {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Unauthorized"})
+		return model.NewUnauthorizedError("Unauthorized")
 	}
 	if _, err := utils.ValidateToken(token); err != nil {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+		return model.NewUnauthorizedError("Token tidak valid")
+	}
+
+	scope, err := requireJobScope(c, db)
+	if scope == nil {
+		return err
 	}
 
 	job, err := repository.GetJobByID(db, c.Params("id"))
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+		return model.WrapError(err, "Gagal mengambil data pekerjaan")
 	}
-	if job == nil {
-		return c.Status(404).JSON(fiber.Map{"success": false, "message": "Pekerjaan tidak ditemukan"})
+	if !scope.Allows(job.AlumniID) {
+		return repository.ErrJobNotFound
 	}
 
 	return c.JSON(fiber.Map{"success": true, "data": job})
@@ -78,7 +158,7 @@ func GetJobByIDService(c *fiber.Ctx, db *mongo.Database) error {
 
 // GetJobsByAlumniIDService godoc
 // @Summary Mendapatkan pekerjaan berdasarkan ID alumni
-// @Description Mengembalikan semua pekerjaan yang dimiliki oleh seorang alumni
+// @Description Mengembalikan semua pekerjaan yang dimiliki oleh seorang alumni. Butuh permission job:read_alumni: alumni hanya bisa melihat pekerjaan miliknya dan staff hanya alumni pada jurusannya. Alumni di luar cakupan user dianggap tidak memiliki pekerjaan.
 // @Tags PekerjaanAlumni
 // @Accept json
 // @Produce json
@@ -86,158 +166,216 @@ func GetJobByIDService(c *fiber.Ctx, db *mongo.Database) error {
 // @Success 200 {object} model.SinglePekerjaanResponse "Berhasil mengambil data pekerjaan alumni"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /unair/pekerjaan/alumni/{alumni_id} [get]
 func GetJobsByAlumniIDService(c *fiber.Ctx, db *mongo.Database) error {
 	token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
 	if token == "" {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Unauthorized"})
+		return model.NewUnauthorizedError("Unauthorized")
 	}
 	if _, err := utils.ValidateToken(token); err != nil {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+		return model.NewUnauthorizedError("Token tidak valid")
+	}
+
+	scope, err := requireJobScope(c, db)
+	if scope == nil {
+		return err
 	}
 
 	id := c.Params("alumni_id")
+	aid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return model.NewValidationError("ID alumni tidak valid")
+	}
+	if !scope.Allows(aid) {
+		return model.NewNotFoundError("Tidak ada pekerjaan")
+	}
+
 	jobs, err := repository.GetJobsByAlumniID(db, id)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+		return model.WrapError(err, "Gagal mengambil data pekerjaan")
 	}
 	if len(jobs) == 0 {
-		return c.Status(404).JSON(fiber.Map{"success": false, "message": "Tidak ada pekerjaan"})
+		return model.NewNotFoundError("Tidak ada pekerjaan")
 	}
 
 	return c.JSON(fiber.Map{"success": true, "data": jobs})
 }
 
+// requireJobScope menentukan pekerjaan mana yang boleh diakses user pemilik token
+// berdasarkan cakupan permission: ScopeAll untuk semua pekerjaan, ScopeJurusan untuk
+// pekerjaan alumni pada jurusan user, dan ScopeOwn hanya pekerjaan pada profil alumninya.
+// Seperti requireCurrentAlumni, handler mengembalikan error jika scope nil.
+func requireJobScope(c *fiber.Ctx, db *mongo.Database) (*model.JobScope, error) {
+	switch permissionScope(c) {
+	case model.ScopeAll:
+		return &model.JobScope{Scope: model.ScopeAll}, nil
+	case model.ScopeJurusan:
+		ids, err := repository.GetAlumniIDsByJurusan(db, jurusanScope(c))
+		if err != nil {
+			return nil, model.WrapError(err, "Gagal mengambil data alumni jurusan")
+		}
+		return &model.JobScope{Scope: model.ScopeJurusan, AlumniIDs: ids}, nil
+	}
+
+	alumni, err := requireCurrentAlumni(c, db)
+	if alumni == nil {
+		return nil, err
+	}
+	return &model.JobScope{Scope: model.ScopeOwn, AlumniIDs: []primitive.ObjectID{alumni.ID}}, nil
+}
+
+// resolveJobAlumniID menentukan alumni_id pekerjaan. Dengan scope semua pekerjaan,
+// alumni_id_str wajib dikirim saat membuat pekerjaan. Scope jurusan juga wajib mengirim
+// alumni_id_str dan alumninya harus berada di jurusan user. Scope milik sendiri selalu
+// memakai profil sendiri. Alumni di luar scope ditolak.
+func resolveJobAlumniID(scope model.JobScope, requested string, creating bool) (string, error) {
+	if scope.Scope == model.ScopeAll {
+		if creating && requested == "" {
+			return "", fmt.Errorf("alumni_id_str wajib diisi")
+		}
+		return requested, nil
+	}
+
+	if requested != "" {
+		id, err := primitive.ObjectIDFromHex(requested)
+		if err != nil {
+			return "", fmt.Errorf("alumni_id_str tidak valid")
+		}
+		if !scope.Allows(id) {
+			return "", fmt.Errorf("tidak diizinkan mengelola pekerjaan alumni di luar cakupan Anda")
+		}
+		return requested, nil
+	}
+
+	if !creating {
+		// alumni_id dibiarkan apa adanya
+		return "", nil
+	}
+	if scope.Scope == model.ScopeOwn && len(scope.AlumniIDs) == 1 {
+		return scope.AlumniIDs[0].Hex(), nil
+	}
+	return "", fmt.Errorf("alumni_id_str wajib diisi")
+}
+
 // CreateJobService godoc
 // @Summary Menambahkan pekerjaan baru
-// @Description Membuat entri pekerjaan baru untuk alumni
+// @Description Membuat entri pekerjaan baru. Admin menentukan alumni lewat alumni_id_str; staff juga wajib mengisi alumni_id_str dengan alumni pada jurusannya; alumni hanya bisa menambah pekerjaan ke profilnya sendiri (alumni_id_str boleh dikosongkan).
 // @Tags PekerjaanAlumni
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param data body model.PekerjaanAlumni true "Data Pekerjaan"
-// @Success 200 {object} model.SinglePekerjaanResponse "Berhasil menambahkan data pekerjaan alumni"
+// @Success 201 {object} model.SinglePekerjaanResponse "Berhasil menambahkan data pekerjaan alumni"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse "Akun belum memiliki profil alumni"
 // @Failure 500 {object} model.ErrorResponse
 // @Router /unair/pekerjaan [post]
 func CreateJobService(c *fiber.Ctx, db *mongo.Database) error {
-	token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
-	if token == "" {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Unauthorized"})
-	}
-	if _, err := utils.ValidateToken(token); err != nil {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+	scope, err := requireJobScope(c, db)
+	if scope == nil {
+		return err
 	}
 
 	var job model.PekerjaanAlumni
 	if err := c.BodyParser(&job); err != nil {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Body tidak valid"})
+		return model.NewValidationError("Body tidak valid: " + err.Error())
+	}
+
+	if job.AlumniIDStr, err = resolveJobAlumniID(*scope, job.AlumniIDStr, true); err != nil {
+		return model.NewForbiddenError(err.Error())
 	}
 
 	res, err := repository.CreateJob(db, &job)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+		return model.WrapError(err, "Gagal menambahkan pekerjaan")
 	}
 
+	recordAudit(c, db, model.AuditCreate, model.AuditEntityPekerjaan, res.ID.Hex(), nil, res)
+
 	return c.Status(201).JSON(fiber.Map{"success": true, "message": "Berhasil tambah pekerjaan", "data": res})
 }
 
 // UpdateJobService godoc
 // @Summary Memperbarui data pekerjaan
-// @Description Mengubah data pekerjaan berdasarkan ID
+// @Description Mengubah data pekerjaan berdasarkan ID. Alumni hanya bisa mengubah pekerjaan miliknya dan tidak bisa memindahkannya ke alumni lain; staff hanya pekerjaan alumni pada jurusannya; admin bisa mengubah semua pekerjaan.
 // @Tags PekerjaanAlumni
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "ID Pekerjaan"
 // @Param data body model.PekerjaanAlumni true "Data Pekerjaan Baru"
 // @Success 200 {object} model.SinglePekerjaanResponse "Berhasil mengupdate data pekerjaan alumni"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /unair/pekerjaan/{id} [put]
 func UpdateJobService(c *fiber.Ctx, db *mongo.Database) error {
-	token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
-	if token == "" {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Unauthorized"})
-	}
-	if _, err := utils.ValidateToken(token); err != nil {
-		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+	scope, err := requireJobScope(c, db)
+	if scope == nil {
+		return err
 	}
 
 	var job model.PekerjaanAlumni
 	if err := c.BodyParser(&job); err != nil {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Body tidak valid"})
+		return model.NewValidationError("Body tidak valid: " + err.Error())
+	}
+
+	if job.AlumniIDStr, err = resolveJobAlumniID(*scope, job.AlumniIDStr, false); err != nil {
+		return model.NewForbiddenError(err.Error())
+	}
+
+	before, err := repository.GetJobByID(db, c.Params("id"))
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil data pekerjaan")
 	}
 
-	res, err := repository.UpdateJob(db, c.Params("id"), job)
+	res, err := repository.UpdateJob(db, c.Params("id"), job, *scope)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+		return model.WrapError(err, "Gagal mengupdate pekerjaan")
 	}
 
+	recordAudit(c, db, model.AuditUpdate, model.AuditEntityPekerjaan, res.ID.Hex(), before, res)
+
 	return c.Status(200).JSON(fiber.Map{"success": true, "message": "Berhasil update pekerjaan", "data": res})
 }
 
 // DeleteJobService godoc
 // @Summary Menghapus pekerjaan (soft delete)
-// @Description Menghapus data pekerjaan secara soft delete
+// @Description Memindahkan pekerjaan ke trash. Alumni hanya bisa menghapus pekerjaan miliknya, staff hanya pekerjaan alumni pada jurusannya. Pekerjaan di trash dihapus permanen otomatis setelah masa simpan TRASH_RETENTION_DAYS.
 // @Tags PekerjaanAlumni
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "ID Pekerjaan"
 // @Success 200 {object} model.SinglePekerjaanResponse "Berhasil menghapus data pekerjaan alumni"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /unair/pekerjaan/filter/trash/{id} [put]
 func DeleteJobService(c *fiber.Ctx, db *mongo.Database) error {
-	authHeader := c.Get("Authorization")
-	if authHeader == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Authorization header tidak ada",
-			"success": false,
-		})
-	}
-
-	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-	if tokenString == authHeader {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Format Authorization salah, gunakan Bearer <token>",
-			"success": false,
-		})
-	}
-
-	claims, err := utils.ValidateToken(tokenString)
-	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Token tidak valid: " + err.Error(),
-			"success": false,
-		})
+	scope, err := requireJobScope(c, db)
+	if scope == nil {
+		return err
 	}
 
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "ID pekerjaan wajib diisi",
-			"success": false,
-		})
+		return model.NewValidationError("ID pekerjaan wajib diisi")
 	}
 
-	userID := claims.UserID.Hex()
-	role := claims.Role
-	if role == "" {
-		role = "alumni"
+	if err := repository.SoftDeleteJob(db, id, *scope); err != nil {
+		return model.WrapError(err, "Gagal menghapus pekerjaan")
 	}
 
-	log.Printf("User %s (role: %s) menghapus pekerjaan ID %s", userID, role, id)
-
-	err = repository.SoftDeleteJob(db, id, userID, role)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Gagal menghapus pekerjaan: " + err.Error(),
-			"success": false,
-		})
-	}
+	recordAudit(c, db, model.AuditSoftDelete, model.AuditEntityPekerjaan, id, bson.M{"is_deleted": false}, bson.M{"is_deleted": true})
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Pekerjaan berhasil dihapus",
@@ -245,98 +383,77 @@ func DeleteJobService(c *fiber.Ctx, db *mongo.Database) error {
 	})
 }
 
+// trashSortFields -> field sorting yang diizinkan pada listing trash pekerjaan
+var trashSortFields = map[string]string{
+	"deleted_at":      "deleted_at",
+	"nama_perusahaan": "nama_perusahaan",
+	"nama_alumni":     "nama_alumni",
+}
+
 // GetTrashService godoc
 // @Summary Mendapatkan daftar data yang ada di trash
-// @Description Mengambil semua data pekerjaan yang sudah dihapus (soft delete) berdasarkan role pengguna
+// @Description Mengambil pekerjaan yang sudah dihapus (soft delete) beserta nama alumninya, dengan pagination, sorting, dan pencarian. Default terbaru dihapus lebih dulu. Admin melihat semua, staff hanya pekerjaan alumni pada jurusannya, alumni hanya miliknya.
 // @Tags Trash
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "Nomor halaman" default(1)
+// @Param limit query int false "Jumlah data per halaman (maks 100)" default(10)
+// @Param sortBy query string false "Field sorting" Enums(deleted_at, nama_perusahaan, nama_alumni) default(deleted_at)
+// @Param order query string false "Urutan sorting" Enums(asc, desc) default(desc)
+// @Param search query string false "Kata kunci nama perusahaan atau nama alumni"
 // @Success 200 {object} model.TrashResponse "Berhasil mengambil data trash"
-// @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 404 {object} map[string]interface{} "Tidak ada trash"
-// @Failure 500 {object} map[string]interface{} "Gagal mengambil trash"
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
 // @Router /unair/pekerjaan/filter/trash [get]
 func GetTrashService(c *fiber.Ctx, db *mongo.Database) error {
-
-	// Ambil user_id dan role dari JWT claims
-	userIDAny := c.Locals("user_id")
-	roleAny := c.Locals("role")
-
-	if userIDAny == nil || roleAny == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Unauthorized: claims tidak ditemukan",
-			"success": false,
-		})
+	scope, err := requireJobScope(c, db)
+	if scope == nil {
+		return err
 	}
 
-	userIDHex, ok := userIDAny.(string)
-	if !ok {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "user_id tidak valid",
-			"success": false,
-		})
+	meta, sortField := parseListQuery(c, trashSortFields, "deleted_at")
+	if c.Query("order") == "" {
+		meta.Order = "desc"
 	}
 
-	role, ok := roleAny.(string)
-	if !ok {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "role tidak valid",
-			"success": false,
-		})
-	}
-
-	// Panggil repository
-	jobs, err := repository.GetTrash(db, userIDHex, role)
+	jobs, total, err := repository.GetTrash(db, *scope, meta.Search, sortField, meta.Order, meta.Limit, pageOffset(meta))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil trash: " + err.Error(),
-			"success": false,
-		})
-	}
-
-	if len(jobs) == 0 {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"message": "Tidak ada trash",
-			"success": false,
-		})
+		return model.WrapError(err, "Gagal mengambil trash")
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Data trash berhasil diambil",
-		"success": true,
-		"data":    jobs,
+	return c.JSON(model.TrashResponse{
+		Message: "Data trash berhasil diambil",
+		Success: true,
+		Data:    jobs,
+		Meta:    withTotal(meta, total),
 	})
 }
 
 // RestoreService godoc
 // @Summary Mengembalikan data dari trash
-// @Description Melakukan restore terhadap pekerjaan berdasarkan ID
+// @Description Melakukan restore terhadap pekerjaan berdasarkan ID. Alumni hanya bisa me-restore pekerjaan miliknya, staff hanya pekerjaan alumni pada jurusannya. Ditolak jika alumni pemilik pekerjaan masih di trash.
 // @Tags Trash
 // @Param id path string true "ID pekerjaan"
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} model.TrashResponse "Berhasil restore pekerjaan"
-// @Failure 403 {object} map[string]interface{} "Tidak diizinkan menghapus pekerjaan"
-// @Failure 500 {object} map[string]interface{} "Gagal restore data"
-// @Router /unair/pekerjaan/filter/restore/{id} [get]
+// @Failure 404 {object} model.ErrorResponse "Pekerjaan tidak ada di trash atau bukan milik user"
+// @Failure 409 {object} model.ErrorResponse "Alumni pemilik pekerjaan ada di trash"
+// @Failure 500 {object} model.ErrorResponse "Gagal restore data"
+// @Router /unair/pekerjaan/filter/restore/{id} [put]
 func RestoreService(c *fiber.Ctx, db *mongo.Database) error {
+	scope, err := requireJobScope(c, db)
+	if scope == nil {
+		return err
+	}
 
 	jobID := c.Params("id")
 
-	rows, err := repository.Restore(db, jobID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal restore data: " + err.Error(),
-			"success": false,
-		})
+	if err := repository.Restore(db, jobID, *scope); err != nil {
+		return model.WrapError(err, "Gagal restore data")
 	}
 
-	if rows == 0 {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"message": fmt.Sprintf("Tidak diizinkan menghapus pekerjaan dengan ID %s", jobID),
-			"success": false,
-		})
-	}
+	recordAudit(c, db, model.AuditRestore, model.AuditEntityPekerjaan, jobID, bson.M{"is_deleted": true}, bson.M{"is_deleted": false})
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Pekerjaan berhasil direstore",
@@ -346,36 +463,75 @@ func RestoreService(c *fiber.Ctx, db *mongo.Database) error {
 
 // HardDeleteService godoc
 // @Summary Menghapus data secara permanen
-// @Description Menghapus pekerjaan dari trash berdasarkan ID secara permanen
+// @Description Menghapus pekerjaan dari trash berdasarkan ID secara permanen. Alumni hanya bisa menghapus pekerjaan miliknya, staff hanya pekerjaan alumni pada jurusannya.
 // @Tags Trash
 // @Param id path string true "ID pekerjaan"
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} map[string]interface{} "Pekerjaan berhasil dihapus permanen"
-// @Failure 403 {object} map[string]interface{} "Tidak diizinkan menghapus pekerjaan"
-// @Failure 500 {object} map[string]interface{} "Gagal delete data"
+// @Failure 404 {object} model.ErrorResponse "Pekerjaan tidak ada di trash atau bukan milik user"
+// @Failure 500 {object} model.ErrorResponse "Gagal delete data"
 // @Router /unair/pekerjaan/filter/delete/{id} [delete]
 func HardDeleteService(c *fiber.Ctx, db *mongo.Database) error {
+	scope, err := requireJobScope(c, db)
+	if scope == nil {
+		return err
+	}
 
 	jobID := c.Params("id")
 
-	rows, err := repository.HardDelete(db, jobID)
+	before, err := repository.GetTrashedJobByID(db, jobID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal delete data: " + err.Error(),
-			"success": false,
-		})
+		return model.WrapError(err, "Gagal mengambil data")
 	}
 
-	if rows == 0 {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"message": fmt.Sprintf("Tidak diizinkan menghapus pekerjaan dengan ID %s", jobID),
-			"success": false,
-		})
+	if err := repository.HardDelete(db, jobID, *scope); err != nil {
+		return model.WrapError(err, "Gagal delete data")
 	}
 
+	recordAudit(c, db, model.AuditHardDelete, model.AuditEntityPekerjaan, jobID, before, nil)
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Pekerjaan berhasil dihapus",
 		"success": true,
 	})
-}
\ No newline at end of file
+}
+
+// GetCareerTimelineService godoc
+// @Summary Mendapatkan riwayat karier alumni
+// @Description Mengembalikan profil alumni dan pekerjaannya yang diurutkan berdasarkan tanggal mulai kerja, lengkap dengan masa kerja, pekerjaan yang tumpang tindih, jeda antar pekerjaan, dan pekerjaan saat ini. Staff hanya bisa melihat alumni pada jurusannya.
+// @Tags PekerjaanAlumni
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Alumni"
+// @Success 200 {object} model.CareerTimelineResponse "Berhasil mengambil riwayat karier"
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/alumni/{id}/timeline [get]
+func GetCareerTimelineService(c *fiber.Ctx, db *mongo.Database) error {
+	id := c.Params("id")
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return model.NewValidationError("ID alumni tidak valid")
+	}
+
+	alumni, err := repository.GetAlumniByID(db, id)
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil data alumni")
+	}
+	if !jurusanScope(c).Allows(alumni.Jurusan) {
+		return repository.ErrAlumniNotFound
+	}
+
+	jobs, err := repository.GetJobsByAlumniID(db, id)
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil data pekerjaan")
+	}
+
+	return c.JSON(model.CareerTimelineResponse{
+		Message: "Berhasil mengambil riwayat karier alumni",
+		Success: true,
+		Data:    BuildCareerTimeline(*alumni, jobs, time.Now()),
+	})
+}
@@ -175,3 +175,54 @@ func TestSoftDelete(t *testing.T) {
 		t.Error("expected soft delete = true")
 	}
 }
+
+func TestResolveJobAlumniID(t *testing.T) {
+	own := primitive.NewObjectID()
+	other := primitive.NewObjectID().Hex()
+	alumni := model.JobScope{Scope: model.ScopeOwn, AlumniIDs: []primitive.ObjectID{own}}
+	admin := model.JobScope{Scope: model.ScopeAll}
+
+	if id, err := resolveJobAlumniID(alumni, "", true); err != nil || id != own.Hex() {
+		t.Errorf("alumni create should default to own profile, got %q %v", id, err)
+	}
+	if _, err := resolveJobAlumniID(alumni, other, true); err == nil {
+		t.Error("alumni must not create jobs for another alumni")
+	}
+	if _, err := resolveJobAlumniID(alumni, other, false); err == nil {
+		t.Error("alumni must not move a job to another alumni")
+	}
+	if id, err := resolveJobAlumniID(alumni, "", false); err != nil || id != "" {
+		t.Errorf("alumni update should keep alumni_id, got %q %v", id, err)
+	}
+
+	if _, err := resolveJobAlumniID(admin, "", true); err == nil {
+		t.Error("admin create requires alumni_id_str")
+	}
+	if id, err := resolveJobAlumniID(admin, other, false); err != nil || id != other {
+		t.Errorf("admin may reassign jobs, got %q %v", id, err)
+	}
+}
+
+func TestResolveJobAlumniID_Jurusan(t *testing.T) {
+	inJurusan := primitive.NewObjectID()
+	staff := model.JobScope{Scope: model.ScopeJurusan, AlumniIDs: []primitive.ObjectID{inJurusan}}
+
+	if id, err := resolveJobAlumniID(staff, inJurusan.Hex(), true); err != nil || id != inJurusan.Hex() {
+		t.Errorf("staff may create jobs for alumni in jurusan, got %q %v", id, err)
+	}
+	if _, err := resolveJobAlumniID(staff, "", true); err == nil {
+		t.Error("staff create requires alumni_id_str")
+	}
+	if _, err := resolveJobAlumniID(staff, primitive.NewObjectID().Hex(), false); err == nil {
+		t.Error("staff must not move a job outside jurusan")
+	}
+	if _, err := resolveJobAlumniID(staff, "bukan-id", true); err == nil {
+		t.Error("invalid alumni_id_str must be rejected")
+	}
+
+	// staff tanpa alumni di jurusannya tidak boleh mengakses pekerjaan apa pun
+	empty := model.JobScope{Scope: model.ScopeJurusan}
+	if empty.Allows(inJurusan) {
+		t.Error("empty jurusan scope must not allow any alumni")
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+)
+
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 100
+)
+
+// parseListQuery membaca query ?page=&limit=&sortBy=&order=&search= dan mengembalikan
+// MetaInfo yang sudah dinormalisasi beserta nama field MongoDB untuk sorting.
+// sortBy hanya diterima jika ada di allowedSort, selain itu dipakai defaultSort.
+func parseListQuery(c *fiber.Ctx, allowedSort map[string]string, defaultSort string) (model.MetaInfo, string) {
+	page := c.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+
+	limit := c.QueryInt("limit", defaultPageLimit)
+	if limit < 1 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	sortBy := c.Query("sortBy", defaultSort)
+	sortField, ok := allowedSort[sortBy]
+	if !ok {
+		sortBy = defaultSort
+		sortField = allowedSort[defaultSort]
+	}
+
+	order := strings.ToLower(c.Query("order", "asc"))
+	if order != "desc" {
+		order = "asc"
+	}
+
+	meta := model.MetaInfo{
+		Page:   page,
+		Limit:  limit,
+		SortBy: sortBy,
+		Order:  order,
+		Search: strings.TrimSpace(c.Query("search")),
+	}
+	return meta, sortField
+}
+
+// pageOffset menghitung jumlah dokumen yang dilewati untuk halaman saat ini
+func pageOffset(meta model.MetaInfo) int {
+	return (meta.Page - 1) * meta.Limit
+}
+
+// withTotal mengisi total data dan jumlah halaman pada MetaInfo
+func withTotal(meta model.MetaInfo, total int) model.MetaInfo {
+	meta.Total = total
+	meta.Pages = (total + meta.Limit - 1) / meta.Limit
+	return meta
+}
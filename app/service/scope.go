@@ -0,0 +1,25 @@
+package service
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+)
+
+// permissionScope membaca cakupan akses yang disimpan middleware.Require. Tanpa middleware
+// tersebut cakupan dianggap ScopeOwn agar handler tidak membuka data milik user lain.
+func permissionScope(c *fiber.Ctx) model.PermissionScope {
+	if scope, ok := c.Locals("permission_scope").(model.PermissionScope); ok {
+		return scope
+	}
+	return model.ScopeOwn
+}
+
+// jurusanScope membaca batas jurusan user untuk cakupan ScopeJurusan. Cakupan lain tidak
+// dibatasi jurusan.
+func jurusanScope(c *fiber.Ctx) model.JurusanScope {
+	if permissionScope(c) != model.ScopeJurusan {
+		return model.JurusanScope{}
+	}
+	jurusan, _ := c.Locals("jurusan").([]string)
+	return model.JurusanScope{Restricted: true, Jurusan: jurusan}
+}
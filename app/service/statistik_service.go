@@ -0,0 +1,216 @@
+package service
+
+import (
+	"math"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// parseStatistikFilter membaca filter opsional ?jurusan=&tahun_lulus=
+func parseStatistikFilter(c *fiber.Ctx) model.StatistikFilter {
+	return model.StatistikFilter{
+		Jurusan:    strings.TrimSpace(c.Query("jurusan")),
+		TahunLulus: c.QueryInt("tahun_lulus", 0),
+	}
+}
+
+// SummarizeMasaTunggu menghitung median dan rata-rata dari daftar masa tunggu yang sudah terurut
+func SummarizeMasaTunggu(sortedMonths []int) model.MasaTunggu {
+	n := len(sortedMonths)
+	if n == 0 {
+		return model.MasaTunggu{}
+	}
+
+	var median float64
+	if n%2 == 1 {
+		median = float64(sortedMonths[n/2])
+	} else {
+		median = float64(sortedMonths[n/2-1]+sortedMonths[n/2]) / 2
+	}
+
+	total := 0
+	for _, m := range sortedMonths {
+		total += m
+	}
+
+	return model.MasaTunggu{
+		JumlahAlumni:  n,
+		MedianBulan:   median,
+		RataRataBulan: math.Round(float64(total)/float64(n)*100) / 100,
+	}
+}
+
+// GetStatistikDashboardService godoc
+// @Summary Dashboard statistik tracer study
+// @Description Mengembalikan tingkat keterserapan kerja per angkatan dan jurusan, median masa tunggu kerja pertama, distribusi bidang industri dan lokasi kerja, serta rentang gaji
+// @Tags Statistik
+// @Produce json
+// @Security BearerAuth
+// @Param jurusan query string false "Filter jurusan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Success 200 {object} model.StatistikDashboardResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/statistik [get]
+func GetStatistikDashboardService(c *fiber.Ctx, db *mongo.Database) error {
+	f := parseStatistikFilter(c)
+
+	var dashboard model.StatistikDashboard
+	var err error
+
+	if dashboard.PerAngkatan, err = repository.GetEmploymentRate(db, f, "angkatan"); err != nil {
+		return statistikError(c, err)
+	}
+	if dashboard.PerJurusan, err = repository.GetEmploymentRate(db, f, "jurusan"); err != nil {
+		return statistikError(c, err)
+	}
+
+	months, err := repository.GetMasaTungguBulan(db, f)
+	if err != nil {
+		return statistikError(c, err)
+	}
+	dashboard.MasaTunggu = SummarizeMasaTunggu(months)
+
+	if dashboard.BidangIndustri, err = repository.GetJobDistribution(db, f, "bidang_industri"); err != nil {
+		return statistikError(c, err)
+	}
+	if dashboard.LokasiKerja, err = repository.GetJobDistribution(db, f, "lokasi_kerja"); err != nil {
+		return statistikError(c, err)
+	}
+	if dashboard.RentangGaji, err = repository.GetSalaryBands(db, f); err != nil {
+		return statistikError(c, err)
+	}
+
+	return c.JSON(model.StatistikDashboardResponse{
+		Message: "Berhasil mengambil statistik tracer study",
+		Success: true,
+		Data:    dashboard,
+	})
+}
+
+// GetEmploymentRateService godoc
+// @Summary Tingkat keterserapan kerja alumni
+// @Description Persentase alumni yang sudah bekerja, dikelompokkan per angkatan atau per jurusan
+// @Tags Statistik
+// @Produce json
+// @Security BearerAuth
+// @Param group path string true "Pengelompokan" Enums(angkatan, jurusan)
+// @Param jurusan query string false "Filter jurusan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Success 200 {array} model.EmploymentRate
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/statistik/employment/{group} [get]
+func GetEmploymentRateService(c *fiber.Ctx, db *mongo.Database) error {
+	group := c.Params("group")
+	if group != "angkatan" && group != "jurusan" {
+		return model.NewValidationError("Pengelompokan harus angkatan atau jurusan")
+	}
+
+	results, err := repository.GetEmploymentRate(db, parseStatistikFilter(c), group)
+	if err != nil {
+		return statistikError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil tingkat keterserapan kerja per " + group,
+		"success": true,
+		"data":    results,
+	})
+}
+
+// GetMasaTungguService godoc
+// @Summary Masa tunggu kerja pertama
+// @Description Median dan rata-rata jumlah bulan dari awal tahun lulus sampai tanggal mulai pekerjaan pertama
+// @Tags Statistik
+// @Produce json
+// @Security BearerAuth
+// @Param jurusan query string false "Filter jurusan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Success 200 {object} model.MasaTunggu
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/statistik/masa-tunggu [get]
+func GetMasaTungguService(c *fiber.Ctx, db *mongo.Database) error {
+	months, err := repository.GetMasaTungguBulan(db, parseStatistikFilter(c))
+	if err != nil {
+		return statistikError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil masa tunggu kerja pertama",
+		"success": true,
+		"data":    SummarizeMasaTunggu(months),
+	})
+}
+
+// GetJobDistributionService godoc
+// @Summary Distribusi pekerjaan alumni
+// @Description Jumlah pekerjaan per bidang industri atau per lokasi kerja
+// @Tags Statistik
+// @Produce json
+// @Security BearerAuth
+// @Param field path string true "Field distribusi" Enums(bidang_industri, lokasi_kerja)
+// @Param jurusan query string false "Filter jurusan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Success 200 {array} model.DistribusiItem
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/statistik/distribusi/{field} [get]
+func GetJobDistributionService(c *fiber.Ctx, db *mongo.Database) error {
+	field := c.Params("field")
+	if field != "bidang_industri" && field != "lokasi_kerja" {
+		return model.NewValidationError("Distribusi harus bidang_industri atau lokasi_kerja")
+	}
+
+	results, err := repository.GetJobDistribution(db, parseStatistikFilter(c), field)
+	if err != nil {
+		return statistikError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil distribusi " + field,
+		"success": true,
+		"data":    results,
+	})
+}
+
+// GetSalaryBandsService godoc
+// @Summary Distribusi rentang gaji
+// @Description Jumlah pekerjaan (IDR) per rentang gaji minimum
+// @Tags Statistik
+// @Produce json
+// @Security BearerAuth
+// @Param jurusan query string false "Filter jurusan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Success 200 {array} model.SalaryBand
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/statistik/gaji [get]
+func GetSalaryBandsService(c *fiber.Ctx, db *mongo.Database) error {
+	results, err := repository.GetSalaryBands(db, parseStatistikFilter(c))
+	if err != nil {
+		return statistikError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil distribusi rentang gaji",
+		"success": true,
+		"data":    results,
+	})
+}
+
+func statistikError(c *fiber.Ctx, err error) error {
+	return model.WrapError(err, "Gagal menghitung statistik")
+}
@@ -0,0 +1,33 @@
+package service
+
+import "testing"
+
+func TestSummarizeMasaTunggu_Odd(t *testing.T) {
+	res := SummarizeMasaTunggu([]int{0, 3, 4, 6, 12})
+
+	if res.JumlahAlumni != 5 {
+		t.Errorf("expected 5 alumni, got %d", res.JumlahAlumni)
+	}
+	if res.MedianBulan != 4 {
+		t.Errorf("expected median 4, got %v", res.MedianBulan)
+	}
+	if res.RataRataBulan != 5 {
+		t.Errorf("expected mean 5, got %v", res.RataRataBulan)
+	}
+}
+
+func TestSummarizeMasaTunggu_Even(t *testing.T) {
+	res := SummarizeMasaTunggu([]int{1, 2, 5, 9})
+
+	if res.MedianBulan != 3.5 {
+		t.Errorf("expected median 3.5, got %v", res.MedianBulan)
+	}
+}
+
+func TestSummarizeMasaTunggu_Empty(t *testing.T) {
+	res := SummarizeMasaTunggu(nil)
+
+	if res.JumlahAlumni != 0 || res.MedianBulan != 0 {
+		t.Errorf("expected zero summary, got %+v", res)
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// currentJobStatuses adalah nilai status_pekerjaan yang menandakan pekerjaan masih dijalani
+var currentJobStatuses = map[string]bool{
+	"aktif":   true,
+	"bekerja": true,
+	"current": true,
+}
+
+const oneDay = 24 * time.Hour
+
+// BuildCareerTimeline menyusun riwayat karier: pekerjaan diurutkan menurut tanggal mulai,
+// masa kerja dihitung sampai tanggal selesai (atau now jika belum selesai), lalu pekerjaan
+// yang tumpang tindih dan jeda antar pekerjaan ditandai.
+func BuildCareerTimeline(alumni model.Alumni, jobs []model.PekerjaanAlumni, now time.Time) model.CareerTimeline {
+	sorted := make([]model.PekerjaanAlumni, len(jobs))
+	copy(sorted, jobs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].TanggalMulaiKerja.Before(sorted[j].TanggalMulaiKerja)
+	})
+
+	timeline := model.CareerTimeline{
+		Alumni:    alumni,
+		Pekerjaan: make([]model.TimelineEntry, len(sorted)),
+		Gaps:      []model.TimelineGap{},
+	}
+
+	ends := make([]time.Time, len(sorted))
+	for i, job := range sorted {
+		ends[i] = jobEnd(job, now)
+		timeline.Pekerjaan[i] = model.TimelineEntry{
+			PekerjaanAlumni: job,
+			MasaKerjaHari:   int(ends[i].Sub(job.TanggalMulaiKerja) / oneDay),
+			MasaKerjaBulan:  monthsBetween(job.TanggalMulaiKerja, ends[i]),
+			IsCurrent:       currentJobStatuses[strings.ToLower(strings.TrimSpace(job.StatusPekerjaan))],
+			OverlapDengan:   []primitive.ObjectID{},
+		}
+		if timeline.Pekerjaan[i].IsCurrent {
+			timeline.PekerjaanSaatIni++
+		}
+	}
+
+	for i := range sorted {
+		for j := i + 1; j < len(sorted); j++ {
+			if !sorted[j].TanggalMulaiKerja.Before(ends[i]) {
+				break
+			}
+			if sorted[i].TanggalMulaiKerja.Before(ends[j]) {
+				timeline.Pekerjaan[i].OverlapDengan = append(timeline.Pekerjaan[i].OverlapDengan, sorted[j].ID)
+				timeline.Pekerjaan[j].OverlapDengan = append(timeline.Pekerjaan[j].OverlapDengan, sorted[i].ID)
+			}
+		}
+	}
+
+	// Jeda dihitung dari akhir pekerjaan terlama yang sudah berjalan, sehingga pekerjaan
+	// pendek yang berada di dalam pekerjaan lain tidak memunculkan jeda palsu.
+	var coveredUntil time.Time
+	lastIdx := -1
+	var totalDays int
+	for i, job := range sorted {
+		start := job.TanggalMulaiKerja
+		if lastIdx >= 0 && start.Sub(coveredUntil) >= oneDay {
+			timeline.Gaps = append(timeline.Gaps, model.TimelineGap{
+				Dari:             coveredUntil,
+				Sampai:           start,
+				Hari:             int(start.Sub(coveredUntil) / oneDay),
+				SetelahPekerjaan: sorted[lastIdx].ID,
+				SebelumPekerjaan: job.ID,
+			})
+		}
+
+		// total masa kerja tanpa menghitung dua kali periode yang tumpang tindih
+		if start.Before(coveredUntil) {
+			start = coveredUntil
+		}
+		if ends[i].After(start) {
+			totalDays += int(ends[i].Sub(start) / oneDay)
+		}
+
+		if lastIdx < 0 || ends[i].After(coveredUntil) {
+			coveredUntil = ends[i]
+			lastIdx = i
+		}
+	}
+	timeline.TotalMasaKerjaBulan = totalDays * 12 / 365
+
+	return timeline
+}
+
+// jobEnd mengembalikan tanggal selesai pekerjaan, atau now jika pekerjaan belum selesai
+func jobEnd(job model.PekerjaanAlumni, now time.Time) time.Time {
+	if job.TanggalSelesaiKerja != nil && !job.TanggalSelesaiKerja.IsZero() {
+		return *job.TanggalSelesaiKerja
+	}
+	return now
+}
+
+// monthsBetween menghitung jumlah bulan penuh di antara dua tanggal
+func monthsBetween(from, to time.Time) int {
+	if !to.After(from) {
+		return 0
+	}
+	months := (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+	if to.Day() < from.Day() {
+		months--
+	}
+	return months
+}
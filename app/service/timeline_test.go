@@ -0,0 +1,88 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func datePtr(y int, m time.Month, d int) *time.Time {
+	t := date(y, m, d)
+	return &t
+}
+
+func TestBuildCareerTimeline(t *testing.T) {
+	now := date(2025, time.January, 1)
+	first := model.PekerjaanAlumni{
+		ID:                  primitive.NewObjectID(),
+		NamaPerusahaan:      "A",
+		TanggalMulaiKerja:   date(2020, time.January, 1),
+		TanggalSelesaiKerja: datePtr(2021, time.January, 1),
+		StatusPekerjaan:     "selesai",
+	}
+	second := model.PekerjaanAlumni{
+		ID:                  primitive.NewObjectID(),
+		NamaPerusahaan:      "B",
+		TanggalMulaiKerja:   date(2021, time.July, 1),
+		TanggalSelesaiKerja: nil,
+		StatusPekerjaan:     "Aktif",
+	}
+	third := model.PekerjaanAlumni{
+		ID:                  primitive.NewObjectID(),
+		NamaPerusahaan:      "C",
+		TanggalMulaiKerja:   date(2023, time.March, 1),
+		TanggalSelesaiKerja: datePtr(2023, time.September, 1),
+		StatusPekerjaan:     "selesai",
+	}
+
+	// sengaja tidak urut untuk memastikan timeline diurutkan
+	tl := BuildCareerTimeline(model.Alumni{Nama: "Budi"}, []model.PekerjaanAlumni{third, first, second}, now)
+
+	if len(tl.Pekerjaan) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(tl.Pekerjaan))
+	}
+	if tl.Pekerjaan[0].NamaPerusahaan != "A" || tl.Pekerjaan[1].NamaPerusahaan != "B" || tl.Pekerjaan[2].NamaPerusahaan != "C" {
+		t.Errorf("expected order A, B, C")
+	}
+	if tl.Pekerjaan[0].MasaKerjaBulan != 12 {
+		t.Errorf("expected 12 months for A, got %d", tl.Pekerjaan[0].MasaKerjaBulan)
+	}
+	if tl.Pekerjaan[1].MasaKerjaBulan != 42 {
+		t.Errorf("expected 42 months for B, got %d", tl.Pekerjaan[1].MasaKerjaBulan)
+	}
+	if !tl.Pekerjaan[1].IsCurrent || tl.PekerjaanSaatIni != 1 {
+		t.Errorf("expected B to be the only current job")
+	}
+
+	if len(tl.Pekerjaan[1].OverlapDengan) != 1 || tl.Pekerjaan[1].OverlapDengan[0] != third.ID {
+		t.Errorf("expected B to overlap with C, got %v", tl.Pekerjaan[1].OverlapDengan)
+	}
+	if len(tl.Pekerjaan[0].OverlapDengan) != 0 {
+		t.Errorf("expected A to have no overlap")
+	}
+
+	// C berada di dalam B, jadi hanya ada satu jeda: antara A dan B
+	if len(tl.Gaps) != 1 {
+		t.Fatalf("expected 1 gap, got %d", len(tl.Gaps))
+	}
+	if tl.Gaps[0].SetelahPekerjaan != first.ID || tl.Gaps[0].SebelumPekerjaan != second.ID {
+		t.Errorf("gap should be between A and B")
+	}
+	if tl.Gaps[0].Hari != 181 {
+		t.Errorf("expected 181 days gap, got %d", tl.Gaps[0].Hari)
+	}
+}
+
+func TestBuildCareerTimeline_Empty(t *testing.T) {
+	tl := BuildCareerTimeline(model.Alumni{Nama: "Budi"}, nil, time.Now())
+
+	if len(tl.Pekerjaan) != 0 || len(tl.Gaps) != 0 || tl.TotalMasaKerjaBulan != 0 {
+		t.Errorf("expected empty timeline, got %+v", tl)
+	}
+}
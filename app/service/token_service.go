@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"github.com/noorfarihaf11/clean-arc/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// issueTokenPair membuat access token dan refresh token baru untuk user,
+// lalu menyimpan sesi refresh token ke koleksi refresh_tokens.
+func issueTokenPair(db *mongo.Database, user model.User) (*model.LoginResponse, *model.RefreshToken, error) {
+	accessToken, claims, err := utils.GenerateToken(user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	refreshToken, hash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session := &model.RefreshToken{
+		UserID:          user.ID,
+		TokenHash:       hash,
+		AccessJTI:       claims.ID,
+		AccessExpiresAt: claims.ExpiresAt.Time,
+		ExpiresAt:       time.Now().Add(utils.RefreshTokenTTL()),
+	}
+	if err := repository.CreateRefreshToken(db, session); err != nil {
+		return nil, nil, err
+	}
+
+	return &model.LoginResponse{
+		User:         user,
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(utils.AccessTokenTTL().Seconds()),
+	}, session, nil
+}
+
+func findUserByID(db *mongo.Database, id primitive.ObjectID) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var user model.User
+	if err := db.Collection("users").FindOne(ctx, bson.M{"_id": id}).Decode(&user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// RefreshService godoc
+// @Summary Memperbarui access token
+// @Description Menukar refresh token dengan pasangan access token dan refresh token baru (rotasi). Refresh token lama langsung tidak berlaku. Jika refresh token yang sudah dirotasi dipakai lagi, semua sesi user dicabut; jika dipakai dua kali bersamaan, semua access token dan refresh token yang diterbitkan dari token tersebut dicabut.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body model.RefreshRequest true "Refresh token"
+// @Success 200 {object} model.LoginResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /api/refresh [post]
+func RefreshService(c *fiber.Ctx, db *mongo.Database) error {
+	var req model.RefreshRequest
+	if err := c.BodyParser(&req); err != nil || req.RefreshToken == "" {
+		return model.NewValidationError("refresh_token wajib diisi")
+	}
+
+	session, err := repository.FindRefreshTokenByHash(db, utils.HashToken(req.RefreshToken))
+	if err != nil {
+		return model.WrapError(err, "Gagal memeriksa refresh token")
+	}
+	if session == nil || !session.ExpiresAt.After(time.Now()) {
+		return model.NewUnauthorizedError("Refresh token tidak valid atau expired")
+	}
+
+	// Token yang sudah dicabut dipakai lagi: kemungkinan dicuri, cabut semua sesi user
+	if session.RevokedAt != nil {
+		if _, err := repository.RevokeAllUserTokens(db, session.UserID); err != nil {
+			log.Printf("Gagal mencabut sesi user %s: %v", session.UserID.Hex(), err)
+		}
+		log.Printf("Refresh token yang sudah dicabut dipakai ulang untuk user %s, semua sesi dicabut", session.UserID.Hex())
+		return model.NewUnauthorizedError("Refresh token sudah tidak berlaku")
+	}
+
+	user, err := findUserByID(db, session.UserID)
+	if err != nil {
+		return model.NewUnauthorizedError("User tidak ditemukan")
+	}
+
+	resp, newSession, err := issueTokenPair(db, *user)
+	if err != nil {
+		return model.WrapError(err, "Gagal membuat token")
+	}
+
+	rotated, err := repository.RevokeRefreshToken(db, session.ID, &newSession.ID)
+	if err != nil || !rotated {
+		// request lain sudah merotasi token ini lebih dulu, artinya token yang sama dipakai dua
+		// kali: sesi baru dari request ini dan semua token hasil rotasi request lain dicabut
+		if err := repository.RevokeSessionChain(db, newSession.ID); err != nil {
+			log.Printf("Gagal mencabut sesi baru %s: %v", newSession.ID.Hex(), err)
+		}
+		if err := repository.RevokeSessionChain(db, session.ID); err != nil {
+			log.Printf("Gagal mencabut token turunan sesi %s: %v", session.ID.Hex(), err)
+		}
+		log.Printf("Refresh token dipakai bersamaan untuk user %s, semua token turunannya dicabut", session.UserID.Hex())
+		return model.NewUnauthorizedError("Refresh token sudah tidak berlaku")
+	}
+	if err := repository.RevokeAccessToken(db, session.AccessJTI, session.UserID, session.AccessExpiresAt); err != nil {
+		log.Printf("Gagal mencabut access token lama %s: %v", session.AccessJTI, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Token berhasil diperbarui",
+		"data":    resp,
+	})
+}
+
+// LogoutService godoc
+// @Summary Keluar dari sistem
+// @Description Mencabut access token yang sedang dipakai dan refresh token yang dikirim. Dengan all=true semua sesi user dicabut.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.LogoutRequest false "Refresh token yang ikut dicabut"
+// @Success 200 {object} model.SuccessResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /api/logout [post]
+func LogoutService(c *fiber.Ctx, db *mongo.Database) error {
+	var req model.LogoutRequest
+	_ = c.BodyParser(&req)
+
+	userID, _ := c.Locals("user_id").(primitive.ObjectID)
+	jti, _ := c.Locals("jti").(string)
+	expiresAt, _ := c.Locals("token_expires_at").(time.Time)
+
+	if req.All {
+		n, err := repository.RevokeAllUserTokens(db, userID)
+		if err != nil {
+			return model.WrapError(err, "Gagal mencabut sesi")
+		}
+		log.Printf("User %s logout dari %d sesi", userID.Hex(), n)
+	} else if req.RefreshToken != "" {
+		session, err := repository.FindRefreshTokenByHash(db, utils.HashToken(req.RefreshToken))
+		if err == nil && session != nil && session.UserID == userID {
+			if _, err := repository.RevokeRefreshToken(db, session.ID, nil); err != nil {
+				return model.WrapError(err, "Gagal mencabut refresh token")
+			}
+		}
+	}
+
+	if err := repository.RevokeAccessToken(db, jti, userID, expiresAt); err != nil {
+		return model.WrapError(err, "Gagal mencabut access token")
+	}
+
+	return c.JSON(model.SuccessResponse{
+		Success: true,
+		Message: "Logout berhasil",
+	})
+}
+
+// RevokeUserSessionsService godoc
+// @Summary Mencabut semua sesi user
+// @Description Admin mencabut seluruh refresh token dan access token aktif milik user, misalnya ketika akun diduga disusupi
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID User"
+// @Success 200 {object} model.SuccessResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /api/users/{id}/revoke-sessions [post]
+func RevokeUserSessionsService(c *fiber.Ctx, db *mongo.Database) error {
+	userID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return model.NewValidationError("ID user tidak valid")
+	}
+
+	n, err := repository.RevokeAllUserTokens(db, userID)
+	if err != nil {
+		return model.WrapError(err, "Gagal mencabut sesi")
+	}
+
+	username, _ := c.Locals("username").(string)
+	log.Printf("Admin %s mencabut %d sesi milik user %s", username, n, userID.Hex())
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Semua sesi user berhasil dicabut",
+		"revoked": n,
+	})
+}
+
+// JWKSService godoc
+// @Summary Public key verifikasi token
+// @Description Daftar public key (JWKS) untuk memverifikasi access token, termasuk kunci lama yang masih dalam masa rotasi. Kosong jika token ditandatangani dengan secret HMAC.
+// @Tags Users
+// @Produce json
+// @Success 200 {object} model.JWKSet
+// @Failure 500 {object} model.ErrorResponse
+// @Router /.well-known/jwks.json [get]
+func JWKSService(c *fiber.Ctx) error {
+	set, err := utils.JWKS()
+	if err != nil {
+		return model.WrapError(err, "Gagal memuat JWKS")
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(set)
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/middleware"
+	"github.com/noorfarihaf11/clean-arc/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+const testRefreshToken = "refresh-token-lama"
+
+func setupTokenTest(t *testing.T) *mtest.T {
+	t.Helper()
+	for _, k := range []string{"JWT_PRIVATE_KEY_FILE", "JWT_PREVIOUS_SECRETS", "JWT_PREVIOUS_KEY_FILES", "JWT_ISSUER", "JWT_AUDIENCE"} {
+		t.Setenv(k, "")
+	}
+	t.Setenv("JWT_SECRET", "secret-untuk-test-refresh-minimal-32-karakter")
+	if err := utils.LoadJWTConfig(); err != nil {
+		t.Fatal(err)
+	}
+	return mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+}
+
+func refreshRequest(mt *mtest.T) int {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Post("/", func(c *fiber.Ctx) error {
+		return RefreshService(c, mt.DB)
+	})
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"refresh_token":"`+testRefreshToken+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		mt.Fatal(err)
+	}
+	return resp.StatusCode
+}
+
+func sessionDoc(id, userID primitive.ObjectID, jti string, revoked bool, replacedBy *primitive.ObjectID) bson.D {
+	doc := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "user_id", Value: userID},
+		{Key: "token_hash", Value: utils.HashToken(testRefreshToken)},
+		{Key: "access_jti", Value: jti},
+		{Key: "access_expires_at", Value: time.Now().Add(10 * time.Minute)},
+		{Key: "expires_at", Value: time.Now().Add(24 * time.Hour)},
+	}
+	if revoked {
+		doc = append(doc, bson.E{Key: "revoked_at", Value: time.Now().Add(-time.Minute)})
+	}
+	if replacedBy != nil {
+		doc = append(doc, bson.E{Key: "replaced_by", Value: *replacedBy})
+	}
+	return doc
+}
+
+func cursor(mt *mtest.T, coll string, docs ...bson.D) bson.D {
+	return mtest.CreateCursorResponse(0, mt.DB.Name()+"."+coll, mtest.FirstBatch, docs...)
+}
+
+func modified(n int) bson.D {
+	return mtest.CreateSuccessResponse(bson.E{Key: "n", Value: n}, bson.E{Key: "nModified", Value: n})
+}
+
+// commandsOn mengambil semua command dengan nama dan koleksi tertentu
+func commandsOn(mt *mtest.T, name, coll string) []*event.CommandStartedEvent {
+	var out []*event.CommandStartedEvent
+	for _, e := range mt.GetAllStartedEvents() {
+		if e.CommandName == name && e.Command.Lookup(name).StringValue() == coll {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func firstUpdate(e *event.CommandStartedEvent) bson.Raw {
+	return e.Command.Lookup("updates").Array().Index(0).Value().Document()
+}
+
+func TestRefreshService_Rotation(t *testing.T) {
+	mt := setupTokenTest(t)
+
+	mt.Run("token aktif dirotasi", func(mt *mtest.T) {
+		userID, sessionID := primitive.NewObjectID(), primitive.NewObjectID()
+		mt.AddMockResponses(
+			cursor(mt, "refresh_tokens", sessionDoc(sessionID, userID, "jti-lama", false, nil)),
+			cursor(mt, "users", bson.D{{Key: "_id", Value: userID}, {Key: "username", Value: "budi"}, {Key: "role", Value: "alumni"}}),
+			mtest.CreateSuccessResponse(), // sesi baru
+			modified(1),                   // rotasi sesi lama
+			modified(1),                   // access token lama dicabut
+		)
+
+		if status := refreshRequest(mt); status != fiber.StatusOK {
+			mt.Fatalf("status = %d, want 200", status)
+		}
+
+		inserts := commandsOn(mt, "insert", "refresh_tokens")
+		if len(inserts) != 1 {
+			mt.Fatalf("expected one new session, got %d", len(inserts))
+		}
+		newID := inserts[0].Command.Lookup("documents").Array().Index(0).Value().Document().Lookup("_id").ObjectID()
+
+		updates := commandsOn(mt, "update", "refresh_tokens")
+		if len(updates) != 1 {
+			mt.Fatalf("expected one rotation update, got %d", len(updates))
+		}
+		u := firstUpdate(updates[0])
+		if u.Lookup("q", "_id").ObjectID() != sessionID || u.Lookup("u", "$set", "replaced_by").ObjectID() != newID {
+			mt.Errorf("sesi lama harus diganti dengan sesi baru: %v", u)
+		}
+		if revoked := commandsOn(mt, "update", "revoked_tokens"); len(revoked) != 1 ||
+			firstUpdate(revoked[0]).Lookup("q", "_id").StringValue() != "jti-lama" {
+			mt.Error("access token lama harus dicabut")
+		}
+	})
+}
+
+func TestRefreshService_ReusedRevokedToken(t *testing.T) {
+	mt := setupTokenTest(t)
+
+	mt.Run("semua sesi user dicabut", func(mt *mtest.T) {
+		userID := primitive.NewObjectID()
+		mt.AddMockResponses(
+			cursor(mt, "refresh_tokens", sessionDoc(primitive.NewObjectID(), userID, "jti-lama", true, nil)),
+			cursor(mt, "refresh_tokens", sessionDoc(primitive.NewObjectID(), userID, "jti-aktif", false, nil)),
+			modified(1), // access token sesi aktif dicabut
+			modified(1), // semua refresh token user dicabut
+		)
+
+		if status := refreshRequest(mt); status != fiber.StatusUnauthorized {
+			mt.Fatalf("status = %d, want 401", status)
+		}
+
+		if inserts := commandsOn(mt, "insert", "refresh_tokens"); len(inserts) != 0 {
+			mt.Error("token yang sudah dicabut tidak boleh menghasilkan sesi baru")
+		}
+		updates := commandsOn(mt, "update", "refresh_tokens")
+		if len(updates) != 1 {
+			mt.Fatalf("expected one revoke-all update, got %d", len(updates))
+		}
+		u := firstUpdate(updates[0])
+		if !u.Lookup("multi").Boolean() || u.Lookup("q", "user_id").ObjectID() != userID {
+			mt.Errorf("semua sesi user harus dicabut: %v", u)
+		}
+		if revoked := commandsOn(mt, "update", "revoked_tokens"); len(revoked) != 1 ||
+			firstUpdate(revoked[0]).Lookup("q", "_id").StringValue() != "jti-aktif" {
+			mt.Error("access token sesi aktif harus dicabut")
+		}
+	})
+}
+
+func TestRefreshService_ConcurrentUse(t *testing.T) {
+	mt := setupTokenTest(t)
+
+	mt.Run("semua token turunan dicabut", func(mt *mtest.T) {
+		userID, sessionID, otherID := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+		mt.AddMockResponses(
+			cursor(mt, "refresh_tokens", sessionDoc(sessionID, userID, "jti-lama", false, nil)),
+			cursor(mt, "users", bson.D{{Key: "_id", Value: userID}, {Key: "username", Value: "budi"}, {Key: "role", Value: "alumni"}}),
+			mtest.CreateSuccessResponse(), // sesi baru
+			modified(0),                   // request lain sudah merotasi token lebih dulu
+
+			// rantai sesi baru milik request ini
+			cursor(mt, "refresh_tokens", sessionDoc(primitive.NewObjectID(), userID, "jti-baru", false, nil)),
+			modified(1),
+			modified(1),
+
+			// rantai sesi lama: sudah diganti oleh sesi milik request lain
+			cursor(mt, "refresh_tokens", sessionDoc(sessionID, userID, "jti-lama", true, &otherID)),
+			modified(1),
+			modified(0),
+			cursor(mt, "refresh_tokens", sessionDoc(otherID, userID, "jti-lain", false, nil)),
+			modified(1),
+			modified(1),
+		)
+
+		if status := refreshRequest(mt); status != fiber.StatusUnauthorized {
+			mt.Fatalf("status = %d, want 401", status)
+		}
+
+		inserts := commandsOn(mt, "insert", "refresh_tokens")
+		if len(inserts) != 1 {
+			mt.Fatalf("expected one new session, got %d", len(inserts))
+		}
+		newID := inserts[0].Command.Lookup("documents").Array().Index(0).Value().Document().Lookup("_id").ObjectID()
+
+		looked := map[primitive.ObjectID]bool{}
+		for _, e := range commandsOn(mt, "find", "refresh_tokens") {
+			if id, ok := e.Command.Lookup("filter", "_id").ObjectIDOK(); ok {
+				looked[id] = true
+			}
+		}
+		if !looked[newID] || !looked[sessionID] {
+			mt.Errorf("rantai sesi baru dan sesi lama harus dicabut, yang diperiksa: %v", looked)
+		}
+
+		revokedJTIs := map[string]bool{}
+		for _, e := range commandsOn(mt, "update", "revoked_tokens") {
+			revokedJTIs[firstUpdate(e).Lookup("q", "_id").StringValue()] = true
+		}
+		for _, jti := range []string{"jti-baru", "jti-lama", "jti-lain"} {
+			if !revokedJTIs[jti] {
+				mt.Errorf("access token %s harus dicabut", jti)
+			}
+		}
+
+		var revokedOther bool
+		for _, e := range commandsOn(mt, "update", "refresh_tokens") {
+			if firstUpdate(e).Lookup("q", "_id").ObjectID() == otherID {
+				revokedOther = true
+			}
+		}
+		if !revokedOther {
+			mt.Error("refresh token hasil rotasi request lain harus dicabut")
+		}
+	})
+}
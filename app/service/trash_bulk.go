@@ -0,0 +1,251 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// maxBulkItems membatasi jumlah pekerjaan yang diproses dalam satu request bulk
+const maxBulkItems = 500
+
+// parseBulkTrashRequest memvalidasi pemilihan pekerjaan pada request bulk. Tepat satu cara
+// pemilihan boleh dipakai: daftar ids, atau filter alumni_id dan/atau deleted_before.
+// trashed menandakan operasi pada pekerjaan di trash (restore dan hapus permanen).
+// ID duplikat dibuang dengan urutan dipertahankan.
+func parseBulkTrashRequest(req model.BulkTrashRequest, trashed bool) ([]string, *model.BulkJobFilter, error) {
+	alumniID := strings.TrimSpace(req.AlumniID)
+	deletedBeforeRaw := strings.TrimSpace(req.DeletedBefore)
+	hasFilter := alumniID != "" || deletedBeforeRaw != ""
+
+	switch {
+	case len(req.IDs) > 0 && hasFilter:
+		return nil, nil, fmt.Errorf("isi ids atau filter (alumni_id, deleted_before), tidak keduanya")
+	case len(req.IDs) == 0 && !hasFilter:
+		return nil, nil, fmt.Errorf("ids atau filter (alumni_id, deleted_before) wajib diisi")
+	}
+
+	if len(req.IDs) > 0 {
+		if len(req.IDs) > maxBulkItems {
+			return nil, nil, fmt.Errorf("maksimal %d ids per request", maxBulkItems)
+		}
+		seen := map[string]bool{}
+		ids := make([]string, 0, len(req.IDs))
+		for _, id := range req.IDs {
+			id = strings.TrimSpace(id)
+			if id == "" || seen[id] {
+				continue
+			}
+			seen[id] = true
+			ids = append(ids, id)
+		}
+		return ids, nil, nil
+	}
+
+	f := &model.BulkJobFilter{Trashed: trashed}
+	if alumniID != "" {
+		id, err := primitive.ObjectIDFromHex(alumniID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("alumni_id tidak valid")
+		}
+		f.AlumniID = &id
+	}
+	if deletedBeforeRaw != "" {
+		if !trashed {
+			return nil, nil, fmt.Errorf("deleted_before hanya bisa dipakai untuk pekerjaan di trash")
+		}
+		t, err := time.Parse("2006-01-02", deletedBeforeRaw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("deleted_before harus berformat YYYY-MM-DD")
+		}
+		f.DeletedBefore = &t
+	}
+	return nil, f, nil
+}
+
+// bulkJobAction menjalankan aksi untuk satu pekerjaan; error not found dari repository
+// berarti pekerjaan tidak ditemukan atau di luar scope
+type bulkJobAction func(id string) error
+
+// runBulkTrash membaca request, menentukan daftar pekerjaan, lalu menjalankan action untuk
+// setiap ID dengan scope yang sama seperti endpoint satu per satu. Hasil nil berarti request
+// ditolak dengan error.
+func runBulkTrash(c *fiber.Ctx, db *mongo.Database, scope model.JobScope, trashed bool, action bulkJobAction) (*model.BulkTrashResult, error) {
+	var req model.BulkTrashRequest
+	if err := c.BodyParser(&req); err != nil {
+		return nil, model.NewValidationError("Body tidak valid: " + err.Error())
+	}
+
+	ids, filter, err := parseBulkTrashRequest(req, trashed)
+	if err != nil {
+		return nil, model.NewValidationError(err.Error())
+	}
+
+	result := &model.BulkTrashResult{Results: []model.BulkItemResult{}}
+	if filter != nil {
+		matched, total, err := repository.FindBulkJobIDs(db, *filter, scope, maxBulkItems)
+		if err != nil {
+			return nil, model.WrapError(err, "Gagal mengambil pekerjaan")
+		}
+		for _, id := range matched {
+			ids = append(ids, id.Hex())
+		}
+		result.Sisa = total - len(matched)
+	}
+
+	for _, id := range ids {
+		item := model.BulkItemResult{ID: id, Status: model.BulkStatusOK}
+		if _, err := primitive.ObjectIDFromHex(id); err != nil {
+			item.Status, item.Message = model.BulkStatusInvalidID, "ID pekerjaan tidak valid"
+		} else if err := action(id); err != nil {
+			item.Status, item.Message = bulkItemError(c, id, err)
+		}
+
+		if item.Status == model.BulkStatusOK {
+			result.Berhasil++
+		} else {
+			result.Gagal++
+		}
+		result.Results = append(result.Results, item)
+	}
+	result.Diproses = len(result.Results)
+
+	return result, nil
+}
+
+// bulkItemError menentukan status dan pesan hasil per ID. Seperti ErrorHandler, hanya pesan
+// AppError yang dikirim ke client; penyebab error internal cukup dicatat di log.
+func bulkItemError(c *fiber.Ctx, id string, err error) (string, string) {
+	var appErr *model.AppError
+	errors.As(model.WrapError(err, "Gagal memproses pekerjaan"), &appErr)
+
+	switch appErr.Kind {
+	case model.ErrKindNotFound:
+		return model.BulkStatusNotFound, "Pekerjaan tidak ditemukan atau di luar cakupan Anda"
+	case model.ErrKindConflict:
+		return model.BulkStatusConflict, appErr.Message
+	case model.ErrKindInternal:
+		log.Printf("%s %s: pekerjaan %s: %v", c.Method(), c.Path(), id, err)
+	}
+	return model.BulkStatusError, appErr.Message
+}
+
+func bulkTrashResponse(c *fiber.Ctx, message string, result *model.BulkTrashResult) error {
+	return c.JSON(model.BulkTrashResponse{
+		Message: fmt.Sprintf("%s: %d berhasil, %d gagal", message, result.Berhasil, result.Gagal),
+		Success: true,
+		Data:    *result,
+	})
+}
+
+// BulkDeleteJobService godoc
+// @Summary Memindahkan banyak pekerjaan ke trash
+// @Description Soft delete banyak pekerjaan sekaligus berdasarkan daftar ids atau filter alumni_id. Aturan akses sama dengan endpoint satu per satu: alumni hanya pekerjaan miliknya, staff hanya pekerjaan alumni pada jurusannya. Hasil dilaporkan per ID; maksimal 500 pekerjaan per request, sisanya dilaporkan di field sisa.
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.BulkTrashRequest true "ids atau filter alumni_id"
+// @Success 200 {object} model.BulkTrashResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/pekerjaan/filter/trash/bulk [post]
+func BulkDeleteJobService(c *fiber.Ctx, db *mongo.Database) error {
+	scope, err := requireJobScope(c, db)
+	if scope == nil {
+		return err
+	}
+
+	result, err := runBulkTrash(c, db, *scope, false, func(id string) error {
+		err := repository.SoftDeleteJob(db, id, *scope)
+		if err == nil {
+			recordAudit(c, db, model.AuditSoftDelete, model.AuditEntityPekerjaan, id, bson.M{"is_deleted": false}, bson.M{"is_deleted": true})
+		}
+		return err
+	})
+	if result == nil {
+		return err
+	}
+	return bulkTrashResponse(c, "Bulk hapus pekerjaan selesai", result)
+}
+
+// BulkRestoreService godoc
+// @Summary Mengembalikan banyak pekerjaan dari trash
+// @Description Restore banyak pekerjaan sekaligus berdasarkan daftar ids atau filter alumni_id dan/atau deleted_before (dihapus sebelum tanggal tersebut). Aturan akses sama dengan endpoint satu per satu. Pekerjaan yang terhapus bersama alumninya tidak termasuk, dan pekerjaan yang alumninya masih di trash dilaporkan dengan status conflict.
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.BulkTrashRequest true "ids atau filter alumni_id / deleted_before"
+// @Success 200 {object} model.BulkTrashResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/pekerjaan/filter/restore/bulk [post]
+func BulkRestoreService(c *fiber.Ctx, db *mongo.Database) error {
+	scope, err := requireJobScope(c, db)
+	if scope == nil {
+		return err
+	}
+
+	result, err := runBulkTrash(c, db, *scope, true, func(id string) error {
+		err := repository.Restore(db, id, *scope)
+		if err == nil {
+			recordAudit(c, db, model.AuditRestore, model.AuditEntityPekerjaan, id, bson.M{"is_deleted": true}, bson.M{"is_deleted": false})
+		}
+		return err
+	})
+	if result == nil {
+		return err
+	}
+	return bulkTrashResponse(c, "Bulk restore pekerjaan selesai", result)
+}
+
+// BulkHardDeleteService godoc
+// @Summary Menghapus permanen banyak pekerjaan dari trash
+// @Description Hapus permanen banyak pekerjaan di trash sekaligus berdasarkan daftar ids atau filter alumni_id dan/atau deleted_before (dihapus sebelum tanggal tersebut). Aturan akses sama dengan endpoint satu per satu. Pekerjaan yang terhapus bersama alumninya tidak termasuk.
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.BulkTrashRequest true "ids atau filter alumni_id / deleted_before"
+// @Success 200 {object} model.BulkTrashResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/pekerjaan/filter/delete/bulk [post]
+func BulkHardDeleteService(c *fiber.Ctx, db *mongo.Database) error {
+	scope, err := requireJobScope(c, db)
+	if scope == nil {
+		return err
+	}
+
+	result, err := runBulkTrash(c, db, *scope, true, func(id string) error {
+		before, err := repository.GetTrashedJobByID(db, id)
+		if err != nil {
+			return err
+		}
+		err = repository.HardDelete(db, id, *scope)
+		if err == nil {
+			recordAudit(c, db, model.AuditHardDelete, model.AuditEntityPekerjaan, id, before, nil)
+		}
+		return err
+	})
+	if result == nil {
+		return err
+	}
+	return bulkTrashResponse(c, "Bulk hapus permanen pekerjaan selesai", result)
+}
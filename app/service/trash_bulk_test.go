@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+)
+
+func TestParseBulkTrashRequest_IDs(t *testing.T) {
+	ids, f, err := parseBulkTrashRequest(model.BulkTrashRequest{
+		IDs: []string{" a ", "b", "a", ""},
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f != nil {
+		t.Errorf("expected no filter, got %+v", f)
+	}
+	if len(ids) != 2 || ids[0] != "a" || ids[1] != "b" {
+		t.Errorf("unexpected ids: %v", ids)
+	}
+}
+
+func TestParseBulkTrashRequest_Filter(t *testing.T) {
+	_, f, err := parseBulkTrashRequest(model.BulkTrashRequest{
+		AlumniID:      "665f1c2e8b3a4d0012345678",
+		DeletedBefore: "2024-01-31",
+	}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f == nil || !f.Trashed || f.AlumniID == nil || f.DeletedBefore == nil {
+		t.Fatalf("unexpected filter: %+v", f)
+	}
+	if got := f.DeletedBefore.Format("2006-01-02"); got != "2024-01-31" {
+		t.Errorf("deleted_before = %s", got)
+	}
+}
+
+func TestParseBulkTrashRequest_Invalid(t *testing.T) {
+	tooMany := make([]string, maxBulkItems+1)
+	tests := []struct {
+		name    string
+		req     model.BulkTrashRequest
+		trashed bool
+	}{
+		{"kosong", model.BulkTrashRequest{}, true},
+		{"ids dan filter", model.BulkTrashRequest{IDs: []string{"a"}, AlumniID: "665f1c2e8b3a4d0012345678"}, true},
+		{"terlalu banyak ids", model.BulkTrashRequest{IDs: tooMany}, true},
+		{"alumni_id tidak valid", model.BulkTrashRequest{AlumniID: "xyz"}, true},
+		{"deleted_before format salah", model.BulkTrashRequest{DeletedBefore: "31-01-2024"}, true},
+		{"deleted_before pada data aktif", model.BulkTrashRequest{DeletedBefore: "2024-01-31"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := parseBulkTrashRequest(tt.req, tt.trashed); err == nil {
+				t.Errorf("expected error for %+v", tt.req)
+			}
+		})
+	}
+}
+
+func TestRunBulkTrash_ItemStatus(t *testing.T) {
+	const (
+		okID       = "665f1c2e8b3a4d0012345671"
+		missingID  = "665f1c2e8b3a4d0012345672"
+		conflictID = "665f1c2e8b3a4d0012345673"
+		failingID  = "665f1c2e8b3a4d0012345674"
+	)
+	actionErr := map[string]error{
+		missingID:  repository.ErrJobNotInTrash,
+		conflictID: repository.ErrJobAlumniInTrash,
+		failingID:  errors.New("koneksi putus"),
+	}
+
+	var result *model.BulkTrashResult
+	app := fiber.New()
+	app.Post("/", func(c *fiber.Ctx) error {
+		var err error
+		result, err = runBulkTrash(c, nil, model.JobScope{Scope: model.ScopeAll}, true, func(id string) error {
+			return actionErr[id]
+		})
+		return err
+	})
+
+	body := `{"ids":["` + strings.Join([]string{okID, missingID, conflictID, failingID, "bukan-id"}, `","`) + `"]}`
+	req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if _, err := app.Test(req); err != nil {
+		t.Fatal(err)
+	}
+	if result == nil {
+		t.Fatal("expected result")
+	}
+
+	want := []string{model.BulkStatusOK, model.BulkStatusNotFound, model.BulkStatusConflict, model.BulkStatusError, model.BulkStatusInvalidID}
+	if len(result.Results) != len(want) || result.Berhasil != 1 || result.Gagal != 4 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	for i, status := range want {
+		if result.Results[i].Status != status {
+			t.Errorf("item %d: status = %s, want %s", i, result.Results[i].Status, status)
+		}
+	}
+	if got := result.Results[2].Message; got != repository.ErrJobAlumniInTrash.Message {
+		t.Errorf("conflict message = %q", got)
+	}
+	if got := result.Results[3].Message; strings.Contains(got, "koneksi putus") || got == "" {
+		t.Errorf("pesan error internal tidak boleh dikirim ke client, got %q", got)
+	}
+}
@@ -0,0 +1,364 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// trashPurgeLease adalah nama dokumen lease di collection leases
+	trashPurgeLease = "trash_purge"
+	// trashPurgeBatch membatasi jumlah pekerjaan yang diambil per putaran purge
+	trashPurgeBatch = 500
+	// trashPurgeActor dicatat sebagai pelaku pada audit log hasil purge otomatis
+	trashPurgeActor = "system:trash_purge"
+)
+
+// TrashPurgeConfig -> pengaturan purge otomatis pekerjaan di trash
+type TrashPurgeConfig struct {
+	Enabled   bool
+	Retention time.Duration
+	Interval  time.Duration
+}
+
+// RetentionDays mengembalikan masa simpan trash dalam hari
+func (cfg TrashPurgeConfig) RetentionDays() int {
+	return int(cfg.Retention / (24 * time.Hour))
+}
+
+// TrashPurgeConfigFromEnv membaca TRASH_PURGE_ENABLED (default true), TRASH_RETENTION_DAYS
+// (default 30), dan TRASH_PURGE_INTERVAL (durasi Go, default 1h, minimal 1m)
+func TrashPurgeConfigFromEnv() (TrashPurgeConfig, error) {
+	cfg := TrashPurgeConfig{Enabled: true, Retention: 30 * 24 * time.Hour, Interval: time.Hour}
+
+	if v := strings.TrimSpace(os.Getenv("TRASH_PURGE_ENABLED")); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return cfg, fmt.Errorf("TRASH_PURGE_ENABLED harus true atau false")
+		}
+		cfg.Enabled = enabled
+	}
+	if v := strings.TrimSpace(os.Getenv("TRASH_RETENTION_DAYS")); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 1 {
+			return cfg, fmt.Errorf("TRASH_RETENTION_DAYS harus bilangan bulat minimal 1")
+		}
+		cfg.Retention = time.Duration(days) * 24 * time.Hour
+	}
+	if v := strings.TrimSpace(os.Getenv("TRASH_PURGE_INTERVAL")); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval < time.Minute {
+			return cfg, fmt.Errorf("TRASH_PURGE_INTERVAL harus durasi minimal 1m, misalnya 30m atau 6h")
+		}
+		cfg.Interval = interval
+	}
+
+	return cfg, nil
+}
+
+// TrashPurger menghapus permanen pekerjaan yang sudah melewati masa simpan di trash secara
+// berkala. Beberapa instance server boleh berjalan bersamaan; hanya instance yang memegang
+// lease trash_purge yang menjalankan purge pada satu interval.
+type TrashPurger struct {
+	db       *mongo.Database
+	cfg      TrashPurgeConfig
+	instance string
+
+	mu      sync.Mutex
+	metrics model.TrashPurgeMetrics
+
+	// done ditutup saat worker berhenti
+	done chan struct{}
+}
+
+var (
+	trashPurgerMu      sync.RWMutex
+	currentTrashPurger *TrashPurger
+)
+
+// NewTrashPurger membuat worker purge dengan ID instance unik untuk lease
+func NewTrashPurger(db *mongo.Database, cfg TrashPurgeConfig) *TrashPurger {
+	host, _ := os.Hostname()
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	instance := fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(suffix))
+
+	return &TrashPurger{
+		db:       db,
+		cfg:      cfg,
+		instance: instance,
+		done:     make(chan struct{}),
+		metrics: model.TrashPurgeMetrics{
+			Enabled:       cfg.Enabled,
+			Instance:      instance,
+			RetentionDays: cfg.RetentionDays(),
+			Interval:      cfg.Interval.String(),
+		},
+	}
+}
+
+// StartTrashPurger menjalankan worker purge di background sampai ctx selesai dan menjadikannya
+// worker yang dilaporkan endpoint metrics. Putaran purge yang sedang berjalan saat ctx selesai
+// tetap dituntaskan; gunakan Wait untuk menunggunya. Worker yang dinonaktifkan tetap
+// didaftarkan agar metrics dan report memakai konfigurasi yang sama.
+func StartTrashPurger(ctx context.Context, db *mongo.Database, cfg TrashPurgeConfig) *TrashPurger {
+	p := NewTrashPurger(db, cfg)
+
+	trashPurgerMu.Lock()
+	currentTrashPurger = p
+	trashPurgerMu.Unlock()
+
+	if cfg.Enabled {
+		go func() {
+			defer close(p.done)
+			p.loop(ctx)
+		}()
+	} else {
+		close(p.done)
+	}
+	return p
+}
+
+// Wait menunggu worker yang dijalankan StartTrashPurger berhenti, termasuk putaran purge
+// yang sedang berjalan
+func (p *TrashPurger) Wait() {
+	<-p.done
+}
+
+func currentPurger() *TrashPurger {
+	trashPurgerMu.RLock()
+	defer trashPurgerMu.RUnlock()
+	return currentTrashPurger
+}
+
+func (p *TrashPurger) loop(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		p.RunOnce()
+
+		next := time.Now().Add(p.cfg.Interval)
+		p.mu.Lock()
+		p.metrics.NextRunAt = &next
+		p.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce menjalankan satu putaran purge jika lease berhasil diambil. Lease berlaku selama
+// satu interval sehingga pada satu interval hanya satu instance yang melakukan purge.
+func (p *TrashPurger) RunOnce() {
+	acquired, err := repository.AcquireLease(p.db, trashPurgeLease, p.instance, p.cfg.Interval)
+	if err != nil {
+		p.finish(time.Now(), 0, fmt.Errorf("gagal mengambil lease: %v", err))
+		return
+	}
+	if !acquired {
+		p.mu.Lock()
+		p.metrics.SkippedRuns++
+		p.mu.Unlock()
+		return
+	}
+
+	started := time.Now()
+	purged, err := p.purge(started.Add(-p.cfg.Retention))
+	p.finish(started, purged, err)
+}
+
+// purge menghapus pekerjaan yang dihapus sebelum cutoff per batch dan mencatat setiap
+// penghapusan ke audit log
+func (p *TrashPurger) purge(cutoff time.Time) (int, error) {
+	audit := repository.NewAuditRepository(p.db)
+	purged := 0
+
+	for {
+		jobs, err := repository.FindExpiredTrash(p.db, cutoff, trashPurgeBatch)
+		if err != nil {
+			return purged, err
+		}
+
+		batchPurged := 0
+		for i := range jobs {
+			ok, err := repository.PurgeExpiredJob(p.db, jobs[i].ID, cutoff)
+			if err != nil {
+				return purged, err
+			}
+			if !ok {
+				continue
+			}
+			batchPurged++
+			writeAudit(audit, &model.AuditLog{
+				Actor:      trashPurgeActor,
+				Role:       "system",
+				Action:     model.AuditHardDelete,
+				EntityType: model.AuditEntityPekerjaan,
+				EntityID:   jobs[i].ID.Hex(),
+				Changes:    auditDiff(&jobs[i], nil),
+				Timestamp:  time.Now(),
+			})
+		}
+		purged += batchPurged
+
+		// batch tidak penuh berarti trash sudah habis; batch tanpa penghapusan berarti
+		// semua kandidat direstore bersamaan, berhenti agar tidak berputar terus
+		if len(jobs) < trashPurgeBatch || batchPurged == 0 {
+			return purged, nil
+		}
+	}
+}
+
+func (p *TrashPurger) finish(started time.Time, purged int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.metrics.Runs++
+	p.metrics.LastRunAt = &started
+	p.metrics.LastDurationMs = time.Since(started).Milliseconds()
+	p.metrics.LastPurged = purged
+	p.metrics.TotalPurged += int64(purged)
+	p.metrics.LastError = ""
+	if err != nil {
+		p.metrics.FailedRuns++
+		p.metrics.LastError = err.Error()
+		log.Printf("Purge trash pekerjaan gagal: %v", err)
+	} else if purged > 0 {
+		log.Printf("Purge trash pekerjaan: %d pekerjaan dihapus permanen", purged)
+	}
+}
+
+// Metrics mengembalikan salinan metrics worker
+func (p *TrashPurger) Metrics() model.TrashPurgeMetrics {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.metrics
+}
+
+// trashPurgeConfig mengembalikan konfigurasi worker yang berjalan, atau konfigurasi dari env
+// jika worker belum dijalankan
+func trashPurgeConfig() TrashPurgeConfig {
+	if p := currentPurger(); p != nil {
+		return p.cfg
+	}
+	cfg, _ := TrashPurgeConfigFromEnv()
+	return cfg
+}
+
+// GetTrashPurgeReportService godoc
+// @Summary Dry-run purge otomatis trash pekerjaan
+// @Description Menampilkan pekerjaan di trash yang akan dihapus permanen oleh purge otomatis karena sudah melewati masa simpan, tanpa menghapus apa pun. retention_days dapat diisi untuk melihat hasil dengan masa simpan lain. Pekerjaan yang terhapus bersama alumninya tidak termasuk.
+// @Tags Trash
+// @Produce json
+// @Security BearerAuth
+// @Param retention_days query int false "Masa simpan dalam hari (default dari TRASH_RETENTION_DAYS)"
+// @Param limit query int false "Jumlah pekerjaan yang ditampilkan (maks 100)" default(100)
+// @Success 200 {object} model.TrashPurgeReportResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /unair/pekerjaan/filter/trash/purge-report [get]
+func GetTrashPurgeReportService(c *fiber.Ctx, db *mongo.Database) error {
+	days := trashPurgeConfig().RetentionDays()
+	if v := c.Query("retention_days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return model.NewValidationError("retention_days harus bilangan bulat minimal 1")
+		}
+		days = n
+	}
+
+	limit := c.QueryInt("limit", maxPageLimit)
+	if limit < 1 || limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	cutoff := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+
+	total, err := repository.CountExpiredTrash(db, cutoff)
+	if err != nil {
+		return model.WrapError(err, "Gagal menghitung trash")
+	}
+	jobs, err := repository.FindExpiredTrash(db, cutoff, limit)
+	if err != nil {
+		return model.WrapError(err, "Gagal mengambil trash")
+	}
+
+	candidates := make([]model.TrashPurgeCandidate, 0, len(jobs))
+	for _, j := range jobs {
+		deletedAt := j.DeletedAt
+		if deletedAt == nil {
+			updatedAt := j.UpdatedAt
+			deletedAt = &updatedAt
+		}
+		candidates = append(candidates, model.TrashPurgeCandidate{
+			ID:             j.ID,
+			AlumniID:       j.AlumniID,
+			NamaPerusahaan: j.NamaPerusahaan,
+			DeletedAt:      deletedAt,
+		})
+	}
+
+	return c.JSON(model.TrashPurgeReportResponse{
+		Message: "Dry-run purge trash pekerjaan",
+		Success: true,
+		Data: model.TrashPurgeReport{
+			RetentionDays: days,
+			Cutoff:        cutoff,
+			Total:         total,
+			Jobs:          candidates,
+		},
+	})
+}
+
+// GetTrashPurgeMetricsService godoc
+// @Summary Metrics purge otomatis trash pekerjaan
+// @Description Menampilkan status worker purge pada instance server yang menerima request: konfigurasi, jumlah putaran, putaran yang dilewati karena lease dipegang instance lain, jumlah pekerjaan yang dipurge, dan error terakhir.
+// @Tags Trash
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.TrashPurgeMetricsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Router /unair/pekerjaan/filter/trash/purge-metrics [get]
+func GetTrashPurgeMetricsService(c *fiber.Ctx) error {
+	p := currentPurger()
+	if p == nil {
+		cfg := trashPurgeConfig()
+		return c.JSON(model.TrashPurgeMetricsResponse{
+			Message: "Worker purge trash tidak berjalan",
+			Success: true,
+			Data: model.TrashPurgeMetrics{
+				RetentionDays: cfg.RetentionDays(),
+				Interval:      cfg.Interval.String(),
+			},
+		})
+	}
+
+	return c.JSON(model.TrashPurgeMetricsResponse{
+		Message: "Berhasil mengambil metrics purge trash",
+		Success: true,
+		Data:    p.Metrics(),
+	})
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTrashPurgeConfigFromEnv(t *testing.T) {
+	t.Setenv("TRASH_PURGE_ENABLED", "")
+	t.Setenv("TRASH_RETENTION_DAYS", "")
+	t.Setenv("TRASH_PURGE_INTERVAL", "")
+
+	cfg, err := TrashPurgeConfigFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Enabled || cfg.RetentionDays() != 30 || cfg.Interval != time.Hour {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+
+	t.Setenv("TRASH_PURGE_ENABLED", "false")
+	t.Setenv("TRASH_RETENTION_DAYS", "7")
+	t.Setenv("TRASH_PURGE_INTERVAL", "15m")
+
+	cfg, err = TrashPurgeConfigFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Enabled || cfg.RetentionDays() != 7 || cfg.Interval != 15*time.Minute {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestTrashPurgeConfigFromEnv_Invalid(t *testing.T) {
+	tests := []struct{ key, value string }{
+		{"TRASH_PURGE_ENABLED", "kadang"},
+		{"TRASH_RETENTION_DAYS", "0"},
+		{"TRASH_RETENTION_DAYS", "30d"},
+		{"TRASH_PURGE_INTERVAL", "10s"},
+		{"TRASH_PURGE_INTERVAL", "sejam"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			t.Setenv("TRASH_PURGE_ENABLED", "")
+			t.Setenv("TRASH_RETENTION_DAYS", "")
+			t.Setenv("TRASH_PURGE_INTERVAL", "")
+			t.Setenv(tt.key, tt.value)
+
+			if _, err := TrashPurgeConfigFromEnv(); err == nil {
+				t.Errorf("expected error for %s=%s", tt.key, tt.value)
+			}
+		})
+	}
+}
+
+func TestStartTrashPurger_StopsWhenContextDone(t *testing.T) {
+	prev := currentPurger()
+	t.Cleanup(func() {
+		trashPurgerMu.Lock()
+		currentTrashPurger = prev
+		trashPurgerMu.Unlock()
+	})
+
+	for _, enabled := range []bool{true, false} {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// ctx sudah selesai sehingga worker berhenti tanpa menyentuh database
+		p := StartTrashPurger(ctx, nil, TrashPurgeConfig{Enabled: enabled, Retention: 24 * time.Hour, Interval: time.Hour})
+
+		done := make(chan struct{})
+		go func() {
+			p.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("Wait tidak kembali setelah ctx selesai (enabled=%v)", enabled)
+		}
+	}
+}
@@ -21,12 +21,12 @@ import (
 // @Accept json
 // @Produce json
 // @Param request body model.LoginRequest true "Data login user"
-// @Success 200 {object} map[string]interface{} "token dan data user"
+// @Success 200 {object} model.LoginResponse "access token, refresh token, dan data user"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /api/login [post]
-func LoginService(db *mongo.Database, req model.LoginRequest) (string, *model.User, error) {
+func LoginService(db *mongo.Database, req model.LoginRequest) (*model.LoginResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -42,83 +42,183 @@ func LoginService(db *mongo.Database, req model.LoginRequest) (string, *model.Us
 	err := db.Collection("users").FindOne(ctx, filter).Decode(&user)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return "", nil, errors.New("username atau password salah")
+			return nil, model.NewUnauthorizedError("username atau password salah")
 		}
-		return "", nil, err
+		return nil, model.NewInternalError("Gagal mengambil data user", err)
 	}
 
 	if !utils.CheckPassword(req.Password, user.PasswordHash) {
-		return "", nil, errors.New("password salah")
+		return nil, model.NewUnauthorizedError("password salah")
 	}
 
-	token, err := utils.GenerateToken(user)
+	resp, _, err := issueTokenPair(db, user)
 	if err != nil {
-		return "", nil, errors.New("gagal generate token")
+		return nil, model.NewInternalError("gagal generate token", err)
 	}
 
-	return token, &user, nil
+	return resp, nil
+}
+
+// createUserAccount memeriksa keunikan username/email lalu menyimpan user baru lewat save
+// (user saja, user + profil baru, atau user + klaim profil lama). conflicts berisi field yang
+// datanya sudah terpakai.
+func createUserAccount(db *mongo.Database, username, email, password, role string, save func(*model.User) (*model.User, error)) (user *model.User, conflicts []model.FieldError, err error) {
+	usernameTaken, emailTaken, err := repository.FindUserConflicts(db, username, email)
+	if err != nil {
+		return nil, nil, err
+	}
+	if usernameTaken {
+		conflicts = append(conflicts, model.FieldError{Field: "username", Message: "username sudah digunakan"})
+	}
+	if emailTaken {
+		conflicts = append(conflicts, model.FieldError{Field: "email", Message: "email sudah terdaftar"})
+	}
+	if len(conflicts) > 0 {
+		return nil, conflicts, nil
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, nil, errors.New("gagal enkripsi password")
+	}
+
+	user, err = save(&model.User{
+		Username:     username,
+		Email:        email,
+		PasswordHash: hashedPassword,
+		Role:         role,
+		CreatedAt:    time.Now(),
+	})
+	if errors.Is(err, repository.ErrAlumniNIMTaken) {
+		// alumni lain dengan NIM yang sama dibuat di antara pemeriksaan klaim dan insert
+		return nil, []model.FieldError{{Field: "nim", Message: "nim sudah terdaftar"}}, nil
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		// user lain mendaftar dengan data yang sama di antara pengecekan dan insert
+		return nil, []model.FieldError{{Field: "username", Message: "username atau email sudah terdaftar"}}, nil
+	}
+	if errors.Is(err, repository.ErrAlumniAlreadyClaimed) {
+		return nil, []model.FieldError{{Field: "nim", Message: "nim sudah terhubung dengan akun lain"}}, nil
+	}
+	return user, nil, err
 }
 
 // RegisterService godoc
 // @Summary Mendaftar ke dalam sistem
-// @Description Membuat user baru dan mengembalikan token JWT
+// @Description Registrasi mandiri untuk alumni. Username 3-50 karakter (huruf, angka, titik, underscore), email valid, password 8-72 karakter berisi huruf dan angka. Username dan email harus unik. Profil alumni (nim, nama, jurusan, angkatan, tahun_lulus) dibuat bersamaan dengan user secara atomik. Jika NIM sudah ada di data alumni (misalnya diinput admin), data tersebut diklaim dan ditautkan ke akun baru asalkan email sama dengan email di data alumni atau claim_code dari admin cocok. NIM yang sudah tertaut ke akun lain atau memiliki data ganda diteruskan ke admin untuk diperiksa, satu klaim pending per NIM. Dibatasi 5 percobaan per NIM dan 30 percobaan per IP setiap 15 menit. Role hanya boleh kosong atau "alumni"; akun admin dibuat lewat POST /api/users.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param request body model.RegisterRequest true "Data registrasi user"
 // @Success 200 {object} map[string]interface{} "Token dan data user yang terdaftar"
 // @Failure 400 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse "Verifikasi klaim NIM gagal"
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 429 {object} model.ErrorResponse "Terlalu banyak percobaan registrasi untuk NIM atau IP yang sama"
 // @Failure 500 {object} model.ErrorResponse
 // @Router /api/register [post]
 func RegisterService(c *fiber.Ctx, db *mongo.Database) error {
 	var req model.RegisterRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
-			Success: false,
-			Message: "Gagal parse request",
-			Code:    fiber.StatusBadRequest,
-		})
+		return model.NewValidationError("Gagal parse request")
 	}
 
-	hashedPassword, err := utils.HashPassword(req.Password)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
-			Success: false,
-			Message: "Gagal enkripsi password",
-			Code:    fiber.StatusInternalServerError,
-		})
+	req, errs := ValidateRegisterRequest(req)
+	if len(errs) > 0 {
+		return model.NewValidationError("Data registrasi tidak valid", errs...)
 	}
 
-	user := &model.User{
-		Username:     req.Username,
-		Email:        req.Email,
-		PasswordHash: hashedPassword,
-		Role:         req.Role,
-		CreatedAt:    time.Now(),
+	claim, err := planNIMClaim(db, req)
+	if err != nil {
+		return model.WrapError(err, "Gagal memeriksa NIM")
+	}
+	if claim.rejection != "" {
+		return &model.AppError{Kind: claim.kind, Message: claim.rejection}
 	}
 
-	createdUser, err := repository.RegisterUser(db, user)
+	createdUser, conflicts, err := createUserAccount(db, req.Username, req.Email, req.Password, req.Role, func(u *model.User) (*model.User, error) {
+		if claim.existing != nil {
+			return repository.RegisterUserClaimingAlumni(db, u, claim.existing.ID, claim.codeID)
+		}
+		return repository.RegisterUser(db, u, registerAlumniProfile(req))
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
-			Success: false,
-			Message: "Gagal membuat user: " + err.Error(),
-			Code:    fiber.StatusInternalServerError,
-		})
+		return model.WrapError(err, "Gagal membuat user")
 	}
+	if len(conflicts) > 0 {
+		return model.NewConflictError("Data registrasi sudah terdaftar", conflicts...)
+	}
+
+	// registrasi mandiri tidak membawa token, sehingga pelakunya adalah user yang baru dibuat
+	entry := newAuditLog(c, model.AuditCreate, model.AuditEntityUser, createdUser.ID.Hex(), nil, createdUser)
+	entry.ActorID = &createdUser.ID
+	entry.Actor = createdUser.Username
+	entry.Role = createdUser.Role
+	writeAudit(repository.NewAuditRepository(db), entry)
 
-	token, err := utils.GenerateToken(*createdUser)
+	tokens, _, err := issueTokenPair(db, *createdUser)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
-			Success: false,
-			Message: "Gagal membuat token JWT",
-			Code:    fiber.StatusInternalServerError,
-		})
+		return model.WrapError(err, "Gagal membuat token JWT")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success":       true,
+		"message":       "Registrasi berhasil",
+		"token":         tokens.Token,
+		"refresh_token": tokens.RefreshToken,
+		"expires_in":    tokens.ExpiresIn,
+		"user":          createdUser,
+	})
+}
+
+// CreateUserService godoc
+// @Summary Membuat user baru (admin)
+// @Description Admin membuat akun dengan role admin, alumni, atau staff. Staff wajib diberi daftar jurusan yang datanya boleh ia akses. Aturan validasi sama dengan registrasi mandiri. Tidak mengembalikan token; user login sendiri dengan password yang diberikan.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.CreateUserRequest true "Data user baru"
+// @Success 201 {object} map[string]interface{} "Data user yang dibuat"
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /api/users [post]
+func CreateUserService(c *fiber.Ctx, db *mongo.Database) error {
+	var req model.CreateUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return model.NewValidationError("Gagal parse request")
+	}
+
+	req, errs := ValidateCreateUserRequest(req)
+	if len(errs) > 0 {
+		return model.NewValidationError("Data user tidak valid", errs...)
+	}
+
+	// user alumni yang dibuat admin mendapat profil awal yang dilengkapi kemudian
+	var alumni *model.Alumni
+	if req.Role == model.RoleAlumni {
+		alumni = &model.Alumni{Nama: req.Username, Email: req.Email}
+	}
+
+	createdUser, conflicts, err := createUserAccount(db, req.Username, req.Email, req.Password, req.Role, func(u *model.User) (*model.User, error) {
+		u.Jurusan = req.Jurusan
+		return repository.RegisterUser(db, u, alumni)
+	})
+	if err != nil {
+		return model.WrapError(err, "Gagal membuat user")
+	}
+	if len(conflicts) > 0 {
+		return model.NewConflictError("Username atau email sudah terdaftar", conflicts...)
+	}
+
+	recordAudit(c, db, model.AuditCreate, model.AuditEntityUser, createdUser.ID.Hex(), nil, createdUser)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"success": true,
-		"message": "Registrasi berhasil",
-		"token":   token,
+		"message": "User berhasil dibuat",
 		"user":    createdUser,
 	})
 }
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+)
+
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.]{3,50}$`)
+
+const (
+	minPasswordLength = 8
+	// bcrypt hanya memakai 72 byte pertama
+	maxPasswordLength = 72
+)
+
+// assignableRoles adalah role yang boleh diberikan admin saat membuat user
+var assignableRoles = map[string]bool{
+	model.RoleAdmin:  true,
+	model.RoleAlumni: true,
+	model.RoleStaff:  true,
+}
+
+// normalizeUserInput merapikan username dan email; email disimpan dalam huruf kecil
+func normalizeUserInput(username, email, role string) (string, string, string) {
+	return strings.TrimSpace(username),
+		strings.ToLower(strings.TrimSpace(email)),
+		strings.ToLower(strings.TrimSpace(role))
+}
+
+// validateUserFields memeriksa format username, email, dan kebijakan password
+func validateUserFields(username, email, password string) []model.FieldError {
+	var errs []model.FieldError
+
+	if !usernamePattern.MatchString(username) {
+		errs = append(errs, model.FieldError{Field: "username", Message: "username harus 3-50 karakter berupa huruf, angka, titik, atau underscore"})
+	}
+	if email == "" {
+		errs = append(errs, model.FieldError{Field: "email", Message: "email wajib diisi"})
+	} else if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		errs = append(errs, model.FieldError{Field: "email", Message: "email tidak valid"})
+	}
+
+	return append(errs, ValidatePassword(password, username)...)
+}
+
+// ValidatePassword menerapkan kebijakan password: 8-72 karakter, memuat huruf dan angka,
+// dan tidak sama dengan username
+func ValidatePassword(password, username string) []model.FieldError {
+	var errs []model.FieldError
+
+	if len(password) < minPasswordLength {
+		errs = append(errs, model.FieldError{Field: "password", Message: "password minimal 8 karakter"})
+	}
+	if len(password) > maxPasswordLength {
+		errs = append(errs, model.FieldError{Field: "password", Message: "password maksimal 72 karakter"})
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		errs = append(errs, model.FieldError{Field: "password", Message: "password harus mengandung huruf dan angka"})
+	}
+	if username != "" && strings.EqualFold(password, username) {
+		errs = append(errs, model.FieldError{Field: "password", Message: "password tidak boleh sama dengan username"})
+	}
+
+	return errs
+}
+
+// ValidateRegisterRequest memvalidasi registrasi mandiri. Role kosong dianggap alumni;
+// role lain ditolak karena admin hanya bisa dibuat oleh admin. Data alumni (nim, jurusan,
+// angkatan) wajib diisi karena profil alumni dibuat bersamaan dengan user; nama kosong
+// diisi dengan username.
+func ValidateRegisterRequest(req model.RegisterRequest) (model.RegisterRequest, []model.FieldError) {
+	req.Username, req.Email, req.Role = normalizeUserInput(req.Username, req.Email, req.Role)
+	req.NIM = strings.TrimSpace(req.NIM)
+	req.Nama = strings.TrimSpace(req.Nama)
+	req.Jurusan = strings.TrimSpace(req.Jurusan)
+	if req.Nama == "" {
+		req.Nama = req.Username
+	}
+
+	errs := validateUserFields(req.Username, req.Email, req.Password)
+
+	if !nimPattern.MatchString(req.NIM) {
+		errs = append(errs, model.FieldError{Field: "nim", Message: "nim harus berupa 6-15 digit angka"})
+	}
+	if req.Jurusan == "" {
+		errs = append(errs, model.FieldError{Field: "jurusan", Message: "jurusan wajib diisi"})
+	}
+	if req.Angkatan <= 0 {
+		errs = append(errs, model.FieldError{Field: "angkatan", Message: "angkatan wajib diisi"})
+	}
+	if req.TahunLulus < 0 {
+		errs = append(errs, model.FieldError{Field: "tahun_lulus", Message: "tahun_lulus harus berupa tahun"})
+	} else if req.TahunLulus > 0 && req.Angkatan > req.TahunLulus {
+		errs = append(errs, model.FieldError{Field: "angkatan", Message: "angkatan tidak boleh lebih besar dari tahun_lulus"})
+	}
+
+	if req.Role == "" {
+		req.Role = model.RoleAlumni
+	}
+	if req.Role != model.RoleAlumni {
+		errs = append(errs, model.FieldError{Field: "role", Message: "registrasi mandiri hanya untuk role alumni"})
+	}
+
+	return req, errs
+}
+
+// ValidateCreateUserRequest memvalidasi user yang dibuat oleh admin. Staff wajib memiliki
+// minimal satu jurusan; role lain tidak boleh diberi jurusan karena aksesnya tidak
+// dibatasi jurusan.
+func ValidateCreateUserRequest(req model.CreateUserRequest) (model.CreateUserRequest, []model.FieldError) {
+	req.Username, req.Email, req.Role = normalizeUserInput(req.Username, req.Email, req.Role)
+	req.Jurusan = normalizeJurusanList(req.Jurusan)
+	errs := validateUserFields(req.Username, req.Email, req.Password)
+
+	if !assignableRoles[req.Role] {
+		errs = append(errs, model.FieldError{Field: "role", Message: "role harus admin, alumni, atau staff"})
+	}
+	if req.Role == model.RoleStaff && len(req.Jurusan) == 0 {
+		errs = append(errs, model.FieldError{Field: "jurusan", Message: "jurusan wajib diisi untuk role staff"})
+	}
+	if req.Role != model.RoleStaff && len(req.Jurusan) > 0 {
+		errs = append(errs, model.FieldError{Field: "jurusan", Message: "jurusan hanya untuk role staff"})
+	}
+
+	return req, errs
+}
+
+// normalizeJurusanList merapikan daftar jurusan: spasi dibuang, nilai kosong dan duplikat
+// (tanpa membedakan huruf besar/kecil) dihapus
+func normalizeJurusanList(list []string) []string {
+	var result []string
+	seen := map[string]bool{}
+	for _, j := range list {
+		j = strings.TrimSpace(j)
+		key := strings.ToLower(j)
+		if j == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, j)
+	}
+	return result
+}
+
+// registerAlumniProfile menyusun profil alumni dari data registrasi
+func registerAlumniProfile(req model.RegisterRequest) *model.Alumni {
+	return &model.Alumni{
+		NIM:        req.NIM,
+		Nama:       req.Nama,
+		Jurusan:    req.Jurusan,
+		Angkatan:   req.Angkatan,
+		TahunLulus: req.TahunLulus,
+		Email:      req.Email,
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/noorfarihaf11/clean-arc/app/model"
+)
+
+func TestValidateRegisterRequest(t *testing.T) {
+	req, errs := ValidateRegisterRequest(model.RegisterRequest{
+		Username: " budi_santoso ",
+		Email:    "Budi@Mail.com",
+		Password: "Rahasia123",
+		NIM:      "187221001",
+		Jurusan:  "Informatika",
+		Angkatan: 2018,
+	})
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if req.Username != "budi_santoso" || req.Email != "budi@mail.com" || req.Role != model.RoleAlumni {
+		t.Errorf("unexpected normalized request: %+v", req)
+	}
+
+	profile := registerAlumniProfile(req)
+	if profile.Nama != "budi_santoso" || profile.NIM != "187221001" || profile.Email != "budi@mail.com" {
+		t.Errorf("unexpected profile: %+v", profile)
+	}
+}
+
+func TestValidateRegisterRequest_RejectsAdminRole(t *testing.T) {
+	_, errs := ValidateRegisterRequest(model.RegisterRequest{
+		Username: "budi",
+		Email:    "budi@mail.com",
+		Password: "Rahasia123",
+		Role:     "Admin",
+		NIM:      "187221001",
+		Jurusan:  "Informatika",
+		Angkatan: 2018,
+	})
+	if len(errs) != 1 || !strings.Contains(errs[0].Message, "alumni") {
+		t.Errorf("expected role to be rejected, got %v", errs)
+	}
+}
+
+func TestValidateRegisterRequest_InvalidFields(t *testing.T) {
+	_, errs := ValidateRegisterRequest(model.RegisterRequest{
+		Username:   "b!",
+		Email:      "bukan-email",
+		Password:   "pendek",
+		NIM:        "abc",
+		Angkatan:   2023,
+		TahunLulus: 2022,
+	})
+	// username, email, panjang password, huruf+angka, nim, jurusan, angkatan > tahun_lulus
+	if len(errs) != 7 {
+		t.Errorf("expected 7 errors, got %v", errs)
+	}
+}
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		password string
+		valid    bool
+	}{
+		{"Rahasia123", true},
+		{"rahasiaaja", false},
+		{"12345678", false},
+		{"budi1234", false}, // sama dengan username
+		{strings.Repeat("a1", 37), false},
+	}
+
+	for _, tt := range tests {
+		errs := ValidatePassword(tt.password, "Budi1234")
+		if (len(errs) == 0) != tt.valid {
+			t.Errorf("ValidatePassword(%q) = %v, want valid=%v", tt.password, errs, tt.valid)
+		}
+	}
+}
+
+func TestValidateCreateUserRequest(t *testing.T) {
+	req, errs := ValidateCreateUserRequest(model.CreateUserRequest{
+		Username: "operator",
+		Email:    "operator@unair.ac.id",
+		Password: "Rahasia123",
+		Role:     "ADMIN",
+	})
+	if len(errs) != 0 || req.Role != model.RoleAdmin {
+		t.Errorf("expected admin to be accepted, got %v %+v", errs, req)
+	}
+
+	_, errs = ValidateCreateUserRequest(model.CreateUserRequest{
+		Username: "operator",
+		Email:    "operator@unair.ac.id",
+		Password: "Rahasia123",
+		Role:     "superuser",
+	})
+	if len(errs) != 1 {
+		t.Errorf("expected unknown role to be rejected, got %v", errs)
+	}
+}
+
+func TestValidateCreateUserRequest_Staff(t *testing.T) {
+	req, errs := ValidateCreateUserRequest(model.CreateUserRequest{
+		Username: "staff_ti",
+		Email:    "staff.ti@unair.ac.id",
+		Password: "Rahasia123",
+		Role:     "staff",
+		Jurusan:  []string{" Teknik Informatika ", "teknik informatika", "", "Sistem Informasi"},
+	})
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(req.Jurusan) != 2 || req.Jurusan[0] != "Teknik Informatika" {
+		t.Errorf("unexpected jurusan: %v", req.Jurusan)
+	}
+
+	_, errs = ValidateCreateUserRequest(model.CreateUserRequest{
+		Username: "staff_ti",
+		Email:    "staff.ti@unair.ac.id",
+		Password: "Rahasia123",
+		Role:     "staff",
+	})
+	if len(errs) != 1 {
+		t.Errorf("expected staff without jurusan to be rejected, got %v", errs)
+	}
+
+	_, errs = ValidateCreateUserRequest(model.CreateUserRequest{
+		Username: "operator",
+		Email:    "operator@unair.ac.id",
+		Password: "Rahasia123",
+		Role:     "admin",
+		Jurusan:  []string{"Teknik Informatika"},
+	})
+	if len(errs) != 1 {
+		t.Errorf("expected jurusan on admin to be rejected, got %v", errs)
+	}
+}
@@ -15,9 +15,164 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Daftar public key (JWKS) untuk memverifikasi access token, termasuk kunci lama yang masih dalam masa rotasi. Kosong jika token ditandatangani dengan secret HMAC.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Public key verifikasi token",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.JWKSet"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/audit-logs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengembalikan riwayat perubahan data (create, update, soft delete, restore, hard delete, upload, import, rollback) beserta pelaku, diff sebelum/sesudah, dan IP. Terbaru lebih dulu kecuali order=asc.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Melihat audit log perubahan data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Jumlah data per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Urutan waktu",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Username atau ID user pelaku",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "soft_delete",
+                            "restore",
+                            "hard_delete",
+                            "upload",
+                            "import",
+                            "rollback"
+                        ],
+                        "type": "string",
+                        "description": "Aksi",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "alumni",
+                            "pekerjaan",
+                            "file",
+                            "user",
+                            "claim_review"
+                        ],
+                        "type": "string",
+                        "description": "Jenis entitas",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID entitas",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal awal (YYYY-MM-DD)",
+                        "name": "dari",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir (YYYY-MM-DD)",
+                        "name": "sampai",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AuditLogResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/files": {
             "get": {
-                "description": "Mengambil daftar semua file beserta metadata-nya",
+                "description": "Mengambil daftar file beserta metadata-nya. Dengan permission file:read:own hanya file milik sendiri yang dikembalikan, dengan file:read:jurusan hanya file alumni pada jurusan user.",
                 "produces": [
                     "application/json"
                 ],
@@ -38,8 +193,7 @@ const docTemplate = `{
                     "500": {
                         "description": "Kesalahan server",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
@@ -77,15 +231,13 @@ const docTemplate = `{
                     "400": {
                         "description": "File tidak valid",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Kesalahan server",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
@@ -130,15 +282,13 @@ const docTemplate = `{
                     "400": {
                         "description": "Kesalahan input",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Kesalahan server",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
@@ -183,15 +333,13 @@ const docTemplate = `{
                     "400": {
                         "description": "Kesalahan input",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Kesalahan server",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
@@ -199,7 +347,7 @@ const docTemplate = `{
         },
         "/api/files/{id}": {
             "get": {
-                "description": "Mengambil metadata dan informasi file sesuai ID",
+                "description": "Mengambil metadata dan informasi file sesuai ID. Dengan permission file:read:own hanya file milik sendiri yang bisa diambil, dengan file:read:jurusan hanya file alumni pada jurusan user.",
                 "produces": [
                     "application/json"
                 ],
@@ -226,21 +374,19 @@ const docTemplate = `{
                     "404": {
                         "description": "File tidak ditemukan",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Kesalahan server",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             },
             "delete": {
-                "description": "Menghapus file dari penyimpanan dan metadata dari database",
+                "description": "Menghapus file dari penyimpanan dan metadata dari database. Dengan permission file:delete:own hanya file milik sendiri yang bisa dihapus.",
                 "produces": [
                     "application/json"
                 ],
@@ -268,15 +414,13 @@ const docTemplate = `{
                     "404": {
                         "description": "File tidak ditemukan",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Kesalahan server",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
@@ -308,10 +452,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "token dan data user",
+                        "description": "access token, refresh token, dan data user",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.LoginResponse"
                         }
                     },
                     "400": {
@@ -335,9 +478,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/register": {
+        "/api/logout": {
             "post": {
-                "description": "Membuat user baru dan mengembalikan token JWT",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencabut access token yang sedang dipakai dan refresh token yang dikirim. Dengan all=true semua sesi user dicabut.",
                 "consumes": [
                     "application/json"
                 ],
@@ -347,28 +495,26 @@ const docTemplate = `{
                 "tags": [
                     "Users"
                 ],
-                "summary": "Mendaftar ke dalam sistem",
+                "summary": "Keluar dari sistem",
                 "parameters": [
                     {
-                        "description": "Data registrasi user",
+                        "description": "Refresh token yang ikut dicabut",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.RegisterRequest"
+                            "$ref": "#/definitions/model.LogoutRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Token dan data user yang terdaftar",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -382,24 +528,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/unair/alumni": {
+        "/api/me": {
             "get": {
-                "description": "Mengembalikan daftar semua alumni yang terdaftar dalam sistem",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Mengambil data alumni yang tertaut ke akun pemilik token",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Alumni"
+                    "Me"
                 ],
-                "summary": "Mengambil semua data alumni",
+                "summary": "Profil alumni milik user yang login",
                 "responses": {
                     "200": {
-                        "description": "Berhasil mengambil data alumni",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.AlumniResponse"
+                            "$ref": "#/definitions/model.SingleAlumniResponse"
                         }
                     },
                     "401": {
@@ -408,6 +556,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -416,8 +570,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Menambahkan data alumni baru ke dalam sistem",
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Alumni hanya dapat mengubah email, no_telepon, dan alamat. Field lain (nim, nama, jurusan, angkatan, tahun_lulus) hanya bisa diubah admin; request yang memuatnya ditolak.",
                 "consumes": [
                     "application/json"
                 ],
@@ -425,23 +584,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Alumni"
+                    "Me"
                 ],
-                "summary": "Menambahkan data alumni baru",
+                "summary": "Mengubah profil alumni sendiri",
                 "parameters": [
                     {
-                        "description": "Data Alumni",
-                        "name": "alumni",
+                        "description": "Field yang diubah",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Alumni"
+                            "$ref": "#/definitions/model.UpdateMyProfileRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Berhasil menambahkan data alumni",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/model.SingleAlumniResponse"
                         }
@@ -458,6 +617,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -467,40 +632,70 @@ const docTemplate = `{
                 }
             }
         },
-        "/unair/alumni/{id}": {
+        "/api/me/files": {
             "get": {
-                "description": "Mengembalikan detail satu alumni berdasarkan ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Mengambil daftar foto dan sertifikat yang diunggah oleh user pemilik token, terbaru lebih dulu",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Alumni"
-                ],
-                "summary": "Mengambil data alumni berdasarkan ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID Alumni",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "File"
                 ],
+                "summary": "Mendapatkan file milik user yang login",
                 "responses": {
-                    "201": {
-                        "description": "Berhasil mengambil data alumni",
+                    "200": {
+                        "description": "Daftar file berhasil diambil",
                         "schema": {
-                            "$ref": "#/definitions/model.SingleAlumniResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.FileResponse"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Token tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Kesalahan server",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/api/me/pekerjaan": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil semua pekerjaan (yang tidak dihapus) dari profil alumni pemilik token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Riwayat pekerjaan milik user yang login",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PekerjaanAlumni"
+                            }
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -521,9 +716,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Mengubah data alumni yang sudah ada dalam sistem",
+            }
+        },
+        "/api/refresh": {
+            "post": {
+                "description": "Menukar refresh token dengan pasangan access token dan refresh token baru (rotasi). Refresh token lama langsung tidak berlaku. Jika refresh token yang sudah dirotasi dipakai lagi, semua sesi user dicabut; jika dipakai dua kali bersamaan, semua access token dan refresh token yang diterbitkan dari token tersebut dicabut.",
                 "consumes": [
                     "application/json"
                 ],
@@ -531,29 +728,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Alumni"
+                    "Users"
                 ],
-                "summary": "Mengubah data alumni",
+                "summary": "Memperbarui access token",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID Alumni",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RefreshRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Alumni"
-                        }
-                    },
-                    "201": {
-                        "description": "Berhasil memperbarui data alumni",
-                        "schema": {
-                            "$ref": "#/definitions/model.SingleAlumniResponse"
+                            "$ref": "#/definitions/model.LoginResponse"
                         }
                     },
                     "400": {
@@ -575,9 +768,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Menghapus data alumni dari sistem",
+            }
+        },
+        "/api/register": {
+            "post": {
+                "description": "Registrasi mandiri untuk alumni. Username 3-50 karakter (huruf, angka, titik, underscore), email valid, password 8-72 karakter berisi huruf dan angka. Username dan email harus unik. Profil alumni (nim, nama, jurusan, angkatan, tahun_lulus) dibuat bersamaan dengan user secara atomik. Jika NIM sudah ada di data alumni (misalnya diinput admin), data tersebut diklaim dan ditautkan ke akun baru asalkan email sama dengan email di data alumni atau claim_code dari admin cocok. NIM yang sudah tertaut ke akun lain atau memiliki data ganda diteruskan ke admin untuk diperiksa, satu klaim pending per NIM. Dibatasi 5 percobaan per NIM dan 30 percobaan per IP setiap 15 menit. Role hanya boleh kosong atau \"alumni\"; akun admin dibuat lewat POST /api/users.",
                 "consumes": [
                     "application/json"
                 ],
@@ -585,23 +780,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Alumni"
+                    "Users"
                 ],
-                "summary": "Menghapus data alumni",
+                "summary": "Mendaftar ke dalam sistem",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID Alumni",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Data registrasi user",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RegisterRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Berhasil menghapus data alumni",
+                    "200": {
+                        "description": "Token dan data user yang terdaftar",
                         "schema": {
-                            "$ref": "#/definitions/model.SingleAlumniResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -610,8 +808,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Verifikasi klaim NIM gagal",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Terlalu banyak percobaan registrasi untuk NIM atau IP yang sama",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -625,9 +835,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/unair/pekerjaan": {
-            "get": {
-                "description": "Mengembalikan daftar semua pekerjaan alumni yang tersedia",
+        "/api/users": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin membuat akun dengan role admin, alumni, atau staff. Staff wajib diberi daftar jurusan yang datanya boleh ia akses. Aturan validasi sama dengan registrasi mandiri. Tidak mengembalikan token; user login sendiri dengan password yang diberikan.",
                 "consumes": [
                     "application/json"
                 ],
@@ -635,14 +850,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PekerjaanAlumni"
+                    "Users"
+                ],
+                "summary": "Membuat user baru (admin)",
+                "parameters": [
+                    {
+                        "description": "Data user baru",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateUserRequest"
+                        }
+                    }
                 ],
-                "summary": "Mendapatkan semua data pekerjaan",
                 "responses": {
-                    "200": {
-                        "description": "Berhasil mengambil data pekerjaan alumni",
+                    "201": {
+                        "description": "Data user yang dibuat",
                         "schema": {
-                            "$ref": "#/definitions/model.PekerjaanAlumniResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -657,6 +884,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -664,35 +903,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/users/{id}/revoke-sessions": {
             "post": {
-                "description": "Membuat entri pekerjaan baru untuk alumni",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Admin mencabut seluruh refresh token dan access token aktif milik user, misalnya ketika akun diduga disusupi",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PekerjaanAlumni"
+                    "Users"
                 ],
-                "summary": "Menambahkan pekerjaan baru",
+                "summary": "Mencabut semua sesi user",
                 "parameters": [
                     {
-                        "description": "Data Pekerjaan",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.PekerjaanAlumni"
-                        }
+                        "type": "string",
+                        "description": "ID User",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Berhasil menambahkan data pekerjaan alumni",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.SinglePekerjaanResponse"
+                            "$ref": "#/definitions/model.SuccessResponse"
                         }
                     },
                     "400": {
@@ -707,6 +948,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -716,9 +963,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/unair/pekerjaan/alumni/{alumni_id}": {
+        "/unair/alumni": {
             "get": {
-                "description": "Mengembalikan semua pekerjaan yang dimiliki oleh seorang alumni",
+                "description": "Mengembalikan daftar alumni dengan pagination, pencarian (nama/nim/jurusan), dan sorting. Staff hanya melihat alumni pada jurusannya.",
                 "consumes": [
                     "application/json"
                 ],
@@ -726,29 +973,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PekerjaanAlumni"
+                    "Alumni"
                 ],
-                "summary": "Mendapatkan pekerjaan berdasarkan ID alumni",
+                "summary": "Mengambil semua data alumni",
                 "parameters": [
                     {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Jumlah data per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "nama",
+                            "nim",
+                            "jurusan",
+                            "angkatan",
+                            "tahun_lulus",
+                            "created_at"
+                        ],
                         "type": "string",
-                        "description": "ID Alumni",
-                        "name": "alumni_id",
-                        "in": "path",
-                        "required": true
+                        "default": "id",
+                        "description": "Field sorting",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Urutan sorting",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian nama, nim, atau jurusan",
+                        "name": "search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Berhasil mengambil data pekerjaan alumni",
-                        "schema": {
-                            "$ref": "#/definitions/model.SinglePekerjaanResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Berhasil mengambil data alumni",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "$ref": "#/definitions/model.AlumniResponse"
                         }
                     },
                     "401": {
@@ -764,168 +1045,328 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/unair/pekerjaan/filter/delete/{id}": {
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+            },
+            "post": {
+                "description": "Menambahkan data alumni baru ke dalam sistem. NIM harus unik di antara alumni aktif. Dengan permission alumni:create:own (tidak diberikan policy bawaan) data alumni langsung ditautkan ke akun pemilik token, dan ditolak jika akun sudah punya profil atau NIM sudah terdaftar; dengan alumni:create:jurusan jurusan alumni harus termasuk jurusan user.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Menghapus pekerjaan dari trash berdasarkan ID secara permanen",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Trash"
+                    "Alumni"
                 ],
-                "summary": "Menghapus data secara permanen",
+                "summary": "Menambahkan data alumni baru",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID pekerjaan",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Data Alumni",
+                        "name": "alumni",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Alumni"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Pekerjaan berhasil dihapus permanen",
+                    "201": {
+                        "description": "Berhasil menambahkan data alumni",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.SingleAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Tidak diizinkan menghapus pekerjaan",
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "NIM sudah terdaftar atau akun sudah punya profil",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Gagal delete data",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/unair/pekerjaan/filter/restore/{id}": {
+        "/unair/alumni/claim-reviews": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Melakukan restore terhadap pekerjaan berdasarkan ID",
+                "description": "Registrasi yang ditolak karena NIM sudah tertaut ke akun lain atau memiliki data alumni ganda, terbaru lebih dulu",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Trash"
+                    "Alumni"
                 ],
-                "summary": "Mengembalikan data dari trash",
+                "summary": "Daftar klaim NIM yang perlu diperiksa",
                 "parameters": [
                     {
+                        "enum": [
+                            "pending",
+                            "resolved",
+                            "rejected"
+                        ],
                         "type": "string",
-                        "description": "ID pekerjaan",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": "pending",
+                        "description": "Status klaim",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Jumlah data per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Berhasil restore pekerjaan",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.TrashResponse"
+                            "$ref": "#/definitions/model.ClaimReviewResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Tidak diizinkan menghapus pekerjaan",
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Gagal restore data",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/unair/pekerjaan/filter/trash": {
-            "get": {
+        "/unair/alumni/claim-reviews/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil semua data pekerjaan yang sudah dihapus (soft delete) berdasarkan role pengguna",
+                "description": "Admin menandai klaim NIM sebagai resolved (data sudah dibereskan, misalnya duplikat digabung) atau rejected (klaim tidak sah)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Trash"
+                    "Alumni"
                 ],
-                "summary": "Mendapatkan daftar data yang ada di trash",
-                "responses": {
-                    "200": {
-                        "description": "Berhasil mengambil data trash",
-                        "schema": {
-                            "$ref": "#/definitions/model.TrashResponse"
-                        }
+                "summary": "Memutuskan klaim NIM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID klaim",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Keputusan admin",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ResolveClaimReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AlumniClaimReview"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Tidak ada trash",
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Gagal mengambil trash",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/unair/pekerjaan/filter/trash/{id}": {
-            "put": {
-                "description": "Menghapus data pekerjaan secara soft delete",
-                "consumes": [
-                    "application/json"
+        "/unair/alumni/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengunduh data alumni dalam format CSV, XLSX, atau NDJSON. Menerima pencarian dan sorting yang sama dengan listing alumni.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Export data alumni",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Format file",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "nama",
+                            "nim",
+                            "jurusan",
+                            "angkatan",
+                            "tahun_lulus",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Field sorting",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Urutan sorting",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian nama, nim, atau jurusan",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni/filter/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Menghapus permanen alumni yang ada di trash beserta semua pekerjaannya dan file milik akun yang tertaut (metadata dan file di storage). Kondisi terakhir alumni tetap tersimpan di riwayat revisi.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PekerjaanAlumni"
+                    "Trash"
                 ],
-                "summary": "Menghapus pekerjaan (soft delete)",
+                "summary": "Menghapus permanen alumni dari trash",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Pekerjaan",
+                        "description": "ID Alumni",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -933,9 +1374,10 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Berhasil menghapus data pekerjaan alumni",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.SinglePekerjaanResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -950,6 +1392,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -959,33 +1413,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/unair/pekerjaan/{id}": {
+        "/unair/alumni/filter/high-salary": {
             "get": {
-                "description": "Mengambil data pekerjaan sesuai ID pekerjaan",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mengembalikan alumni beserta pekerjaan yang gajinya di atas batas min_gaji",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PekerjaanAlumni"
+                    "Alumni"
                 ],
-                "summary": "Mendapatkan pekerjaan berdasarkan ID",
+                "summary": "Mengambil alumni dengan gaji tinggi",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 19000000,
+                        "description": "Batas gaji minimum (eksklusif)",
+                        "name": "min_gaji",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "ID Pekerjaan",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": "IDR",
+                        "description": "Mata uang gaji",
+                        "name": "mata_uang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Berhasil mengambil data pekerjaan alumni",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.SinglePekerjaanResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AlumniWithSalary"
+                            }
                         }
                     },
                     "400": {
@@ -1007,42 +1468,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/unair/alumni/filter/restore/{id}": {
             "put": {
-                "description": "Mengubah data pekerjaan berdasarkan ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Memulihkan alumni beserta pekerjaan yang ikut terhapus bersamanya. Pekerjaan yang sudah ada di trash sebelum alumninya dihapus tetap di trash. Ditolak jika NIM alumni sudah dipakai alumni aktif lain.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PekerjaanAlumni"
+                    "Trash"
                 ],
-                "summary": "Memperbarui data pekerjaan",
+                "summary": "Mengembalikan alumni dari trash",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Pekerjaan",
+                        "description": "ID Alumni",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Data Pekerjaan Baru",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.PekerjaanAlumni"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Berhasil mengupdate data pekerjaan alumni",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.SinglePekerjaanResponse"
+                            "$ref": "#/definitions/model.SingleAlumniResponse"
                         }
                     },
                     "400": {
@@ -1057,6 +1513,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1065,319 +1539,3638 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.Alumni": {
-            "type": "object",
-            "properties": {
-                "alamat": {
-                    "type": "string"
-                },
-                "angkatan": {
-                    "type": "integer"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "jurusan": {
-                    "type": "string"
-                },
-                "nama": {
-                    "type": "string"
+        },
+        "/unair/alumni/filter/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil alumni yang sudah dihapus (soft delete) beserta jumlah pekerjaan yang ikut terhapus bersamanya, dengan pagination, sorting, dan pencarian. Default terbaru dihapus lebih dulu.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Mendapatkan daftar alumni di trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Jumlah data per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "deleted_at",
+                            "nama",
+                            "nim",
+                            "jurusan"
+                        ],
+                        "type": "string",
+                        "default": "deleted_at",
+                        "description": "Field sorting",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Urutan sorting",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kata kunci nama, nim, atau jurusan",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AlumniTrashResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni/filter/year": {
+            "get": {
+                "description": "Mengembalikan alumni yang lulus pada tahun tertentu (default tahun berjalan)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Mengambil alumni berdasarkan tahun lulus",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tahun lulus",
+                        "name": "tahun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Alumni"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni/filter/yearjob": {
+            "get": {
+                "description": "Mengembalikan alumni yang tanggal mulai kerjanya berada di tahun yang sama dengan tahun lulus",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Mengambil alumni yang langsung bekerja di tahun kelulusan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Batasi pada tahun lulus tertentu",
+                        "name": "tahun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AlumniWithYear"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengunggah file CSV atau XLSX berheader (nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat). Setiap baris divalidasi lalu disimpan berdasarkan NIM (dibuat baru atau diperbarui). Kolom opsional (tahun_lulus, email, no_telepon, alamat) yang tidak ada di file atau kosong tidak menimpa data yang sudah tersimpan. Baris yang tidak valid dilaporkan dan dilewati. Gunakan dry_run=true untuk melihat perubahan tanpa menyimpan.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Import data alumni dari CSV/XLSX",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File CSV atau XLSX",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hanya simulasi, tidak menyimpan data",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni/{id}": {
+            "get": {
+                "description": "Mengembalikan detail satu alumni berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Mengambil data alumni berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Berhasil mengambil data alumni",
+                        "schema": {
+                            "$ref": "#/definitions/model.SingleAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Mengubah data alumni yang sudah ada dalam sistem. Staff hanya bisa mengubah alumni pada jurusannya dan tidak bisa memindahkannya ke jurusan lain. Jika jurusan tidak dikirim, jurusan yang tersimpan dipertahankan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Mengubah data alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Alumni"
+                        }
+                    },
+                    "201": {
+                        "description": "Berhasil memperbarui data alumni",
+                        "schema": {
+                            "$ref": "#/definitions/model.SingleAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Memindahkan alumni ke trash (soft delete) bersama pekerjaannya yang masih aktif. Alumni di trash tidak muncul di daftar, statistik, maupun export dan bisa dipulihkan lewat restore.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Memindahkan data alumni ke trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Berhasil menghapus data alumni",
+                        "schema": {
+                            "$ref": "#/definitions/model.SingleAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni/{id}/claim-code": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin menerbitkan kode klaim sekali pakai (berlaku 7 hari) untuk data alumni yang belum tertaut ke akun. Alumni memakai kode ini sebagai claim_code saat registrasi jika emailnya tidak sama dengan email di data alumni. Kode lama yang belum terpakai otomatis hangus. Kode hanya ditampilkan sekali.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Menerbitkan kode klaim alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ClaimCodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengembalikan snapshot data alumni untuk setiap perubahan (create, update, import, hapus permanen, rollback), terbaru lebih dulu kecuali order=asc. Revisi baseline menyimpan kondisi sebelum perubahan pertama yang tercatat. Riwayat alumni yang sudah dihapus tetap bisa dilihat. Staff hanya bisa melihat alumni pada jurusannya.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Melihat riwayat revisi alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Jumlah data per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Urutan versi",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AlumniRevisionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni/{id}/revisions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengembalikan field yang berbeda antara revisi from dan to beserta nilai sebelum/sesudahnya. Default to adalah versi terakhir dan from adalah versi sebelum to.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Membandingkan dua revisi alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Versi awal",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Versi akhir",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AlumniRevisionDiffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni/{id}/revisions/{version}/rollback": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengembalikan data alumni ke snapshot pada versi yang dipilih dan mencatatnya sebagai revisi baru. Alumni yang sudah dihapus permanen dibuat ulang dengan ID yang sama (hanya untuk user dengan akses penuh); alumni di trash harus direstore dulu. Staff hanya bisa mengembalikan alumni ke revisi yang jurusannya masih dalam cakupannya.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Mengembalikan alumni ke revisi tertentu",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Versi tujuan",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SingleAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni/{id}/timeline": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengembalikan profil alumni dan pekerjaannya yang diurutkan berdasarkan tanggal mulai kerja, lengkap dengan masa kerja, pekerjaan yang tumpang tindih, jeda antar pekerjaan, dan pekerjaan saat ini. Staff hanya bisa melihat alumni pada jurusannya.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PekerjaanAlumni"
+                ],
+                "summary": "Mendapatkan riwayat karier alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Berhasil mengambil riwayat karier",
+                        "schema": {
+                            "$ref": "#/definitions/model.CareerTimelineResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan": {
+            "get": {
+                "description": "Mengembalikan daftar pekerjaan alumni dengan pagination, filter, dan sorting. Staff hanya melihat pekerjaan alumni pada jurusannya.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PekerjaanAlumni"
+                ],
+                "summary": "Mendapatkan semua data pekerjaan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Jumlah data per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "nama_perusahaan",
+                            "posisi_jabatan",
+                            "bidang_industri",
+                            "lokasi_kerja",
+                            "tanggal_mulai_kerja",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Field sorting",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Urutan sorting",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kata kunci nama perusahaan atau posisi jabatan",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter bidang industri",
+                        "name": "bidang_industri",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter lokasi kerja",
+                        "name": "lokasi_kerja",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status pekerjaan",
+                        "name": "status_pekerjaan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai kerja paling awal (YYYY-MM-DD)",
+                        "name": "mulai_dari",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai kerja paling akhir (YYYY-MM-DD)",
+                        "name": "mulai_sampai",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Berhasil mengambil data pekerjaan alumni",
+                        "schema": {
+                            "$ref": "#/definitions/model.PekerjaanAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat entri pekerjaan baru. Admin menentukan alumni lewat alumni_id_str; staff juga wajib mengisi alumni_id_str dengan alumni pada jurusannya; alumni hanya bisa menambah pekerjaan ke profilnya sendiri (alumni_id_str boleh dikosongkan).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PekerjaanAlumni"
+                ],
+                "summary": "Menambahkan pekerjaan baru",
+                "parameters": [
+                    {
+                        "description": "Data Pekerjaan",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PekerjaanAlumni"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Berhasil menambahkan data pekerjaan alumni",
+                        "schema": {
+                            "$ref": "#/definitions/model.SinglePekerjaanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Akun belum memiliki profil alumni",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan/alumni/{alumni_id}": {
+            "get": {
+                "description": "Mengembalikan semua pekerjaan yang dimiliki oleh seorang alumni. Butuh permission job:read_alumni: alumni hanya bisa melihat pekerjaan miliknya dan staff hanya alumni pada jurusannya. Alumni di luar cakupan user dianggap tidak memiliki pekerjaan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PekerjaanAlumni"
+                ],
+                "summary": "Mendapatkan pekerjaan berdasarkan ID alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "alumni_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Berhasil mengambil data pekerjaan alumni",
+                        "schema": {
+                            "$ref": "#/definitions/model.SinglePekerjaanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengunduh data pekerjaan dalam format CSV, XLSX, atau NDJSON. Menerima filter dan sorting yang sama dengan listing pekerjaan. Dengan join=alumni setiap baris pekerjaan dilengkapi kolom data alumninya.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "PekerjaanAlumni"
+                ],
+                "summary": "Export data pekerjaan alumni",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Format file",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "alumni"
+                        ],
+                        "type": "string",
+                        "description": "Gabungkan data alumni",
+                        "name": "join",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "nama_perusahaan",
+                            "posisi_jabatan",
+                            "bidang_industri",
+                            "lokasi_kerja",
+                            "tanggal_mulai_kerja",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Field sorting",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Urutan sorting",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kata kunci nama perusahaan atau posisi jabatan",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter bidang industri",
+                        "name": "bidang_industri",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter lokasi kerja",
+                        "name": "lokasi_kerja",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status pekerjaan",
+                        "name": "status_pekerjaan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai kerja paling awal (YYYY-MM-DD)",
+                        "name": "mulai_dari",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai kerja paling akhir (YYYY-MM-DD)",
+                        "name": "mulai_sampai",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan/filter/delete/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hapus permanen banyak pekerjaan di trash sekaligus berdasarkan daftar ids atau filter alumni_id dan/atau deleted_before (dihapus sebelum tanggal tersebut). Aturan akses sama dengan endpoint satu per satu. Pekerjaan yang terhapus bersama alumninya tidak termasuk.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Menghapus permanen banyak pekerjaan dari trash",
+                "parameters": [
+                    {
+                        "description": "ids atau filter alumni_id / deleted_before",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkTrashRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkTrashResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan/filter/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus pekerjaan dari trash berdasarkan ID secara permanen. Alumni hanya bisa menghapus pekerjaan miliknya, staff hanya pekerjaan alumni pada jurusannya.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Menghapus data secara permanen",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pekerjaan berhasil dihapus permanen",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Pekerjaan tidak ada di trash atau bukan milik user",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Gagal delete data",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan/filter/restore/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore banyak pekerjaan sekaligus berdasarkan daftar ids atau filter alumni_id dan/atau deleted_before (dihapus sebelum tanggal tersebut). Aturan akses sama dengan endpoint satu per satu. Pekerjaan yang terhapus bersama alumninya tidak termasuk, dan pekerjaan yang alumninya masih di trash dilaporkan dengan status conflict.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Mengembalikan banyak pekerjaan dari trash",
+                "parameters": [
+                    {
+                        "description": "ids atau filter alumni_id / deleted_before",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkTrashRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkTrashResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan/filter/restore/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melakukan restore terhadap pekerjaan berdasarkan ID. Alumni hanya bisa me-restore pekerjaan miliknya, staff hanya pekerjaan alumni pada jurusannya. Ditolak jika alumni pemilik pekerjaan masih di trash.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Mengembalikan data dari trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Berhasil restore pekerjaan",
+                        "schema": {
+                            "$ref": "#/definitions/model.TrashResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Pekerjaan tidak ada di trash atau bukan milik user",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Alumni pemilik pekerjaan ada di trash",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Gagal restore data",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan/filter/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil pekerjaan yang sudah dihapus (soft delete) beserta nama alumninya, dengan pagination, sorting, dan pencarian. Default terbaru dihapus lebih dulu. Admin melihat semua, staff hanya pekerjaan alumni pada jurusannya, alumni hanya miliknya.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Mendapatkan daftar data yang ada di trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Jumlah data per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "deleted_at",
+                            "nama_perusahaan",
+                            "nama_alumni"
+                        ],
+                        "type": "string",
+                        "default": "deleted_at",
+                        "description": "Field sorting",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Urutan sorting",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kata kunci nama perusahaan atau nama alumni",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Berhasil mengambil data trash",
+                        "schema": {
+                            "$ref": "#/definitions/model.TrashResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan/filter/trash/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete banyak pekerjaan sekaligus berdasarkan daftar ids atau filter alumni_id. Aturan akses sama dengan endpoint satu per satu: alumni hanya pekerjaan miliknya, staff hanya pekerjaan alumni pada jurusannya. Hasil dilaporkan per ID; maksimal 500 pekerjaan per request, sisanya dilaporkan di field sisa.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Memindahkan banyak pekerjaan ke trash",
+                "parameters": [
+                    {
+                        "description": "ids atau filter alumni_id",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkTrashRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkTrashResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan/filter/trash/purge-metrics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan status worker purge pada instance server yang menerima request: konfigurasi, jumlah putaran, putaran yang dilewati karena lease dipegang instance lain, jumlah pekerjaan yang dipurge, dan error terakhir.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Metrics purge otomatis trash pekerjaan",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TrashPurgeMetricsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan/filter/trash/purge-report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan pekerjaan di trash yang akan dihapus permanen oleh purge otomatis karena sudah melewati masa simpan, tanpa menghapus apa pun. retention_days dapat diisi untuk melihat hasil dengan masa simpan lain. Pekerjaan yang terhapus bersama alumninya tidak termasuk.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Dry-run purge otomatis trash pekerjaan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Masa simpan dalam hari (default dari TRASH_RETENTION_DAYS)",
+                        "name": "retention_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Jumlah pekerjaan yang ditampilkan (maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TrashPurgeReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan/filter/trash/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memindahkan pekerjaan ke trash. Alumni hanya bisa menghapus pekerjaan miliknya, staff hanya pekerjaan alumni pada jurusannya. Pekerjaan di trash dihapus permanen otomatis setelah masa simpan TRASH_RETENTION_DAYS.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PekerjaanAlumni"
+                ],
+                "summary": "Menghapus pekerjaan (soft delete)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Berhasil menghapus data pekerjaan alumni",
+                        "schema": {
+                            "$ref": "#/definitions/model.SinglePekerjaanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan/{id}": {
+            "get": {
+                "description": "Mengambil data pekerjaan sesuai ID pekerjaan. Pekerjaan di luar cakupan user dianggap tidak ditemukan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PekerjaanAlumni"
+                ],
+                "summary": "Mendapatkan pekerjaan berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Berhasil mengambil data pekerjaan alumni",
+                        "schema": {
+                            "$ref": "#/definitions/model.SinglePekerjaanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah data pekerjaan berdasarkan ID. Alumni hanya bisa mengubah pekerjaan miliknya dan tidak bisa memindahkannya ke alumni lain; staff hanya pekerjaan alumni pada jurusannya; admin bisa mengubah semua pekerjaan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PekerjaanAlumni"
+                ],
+                "summary": "Memperbarui data pekerjaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Pekerjaan Baru",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PekerjaanAlumni"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Berhasil mengupdate data pekerjaan alumni",
+                        "schema": {
+                            "$ref": "#/definitions/model.SinglePekerjaanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/statistik": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengembalikan tingkat keterserapan kerja per angkatan dan jurusan, median masa tunggu kerja pertama, distribusi bidang industri dan lokasi kerja, serta rentang gaji",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistik"
+                ],
+                "summary": "Dashboard statistik tracer study",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StatistikDashboardResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/statistik/distribusi/{field}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Jumlah pekerjaan per bidang industri atau per lokasi kerja",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistik"
+                ],
+                "summary": "Distribusi pekerjaan alumni",
+                "parameters": [
+                    {
+                        "enum": [
+                            "bidang_industri",
+                            "lokasi_kerja"
+                        ],
+                        "type": "string",
+                        "description": "Field distribusi",
+                        "name": "field",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.DistribusiItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/statistik/employment/{group}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Persentase alumni yang sudah bekerja, dikelompokkan per angkatan atau per jurusan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistik"
+                ],
+                "summary": "Tingkat keterserapan kerja alumni",
+                "parameters": [
+                    {
+                        "enum": [
+                            "angkatan",
+                            "jurusan"
+                        ],
+                        "type": "string",
+                        "description": "Pengelompokan",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.EmploymentRate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/statistik/gaji": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Jumlah pekerjaan (IDR) per rentang gaji minimum",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistik"
+                ],
+                "summary": "Distribusi rentang gaji",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SalaryBand"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/statistik/masa-tunggu": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Median dan rata-rata jumlah bulan dari awal tahun lulus sampai tanggal mulai pekerjaan pertama",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistik"
+                ],
+                "summary": "Masa tunggu kerja pertama",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MasaTunggu"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.Alumni": {
+            "type": "object",
+            "properties": {
+                "alamat": {
+                    "type": "string"
+                },
+                "angkatan": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "deleted_by": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_deleted": {
+                    "type": "boolean"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "no_telepon": {
+                    "type": "string"
+                },
+                "tahun_lulus": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AlumniClaimReview": {
+            "type": "object",
+            "properties": {
+                "alumni_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "catatan": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "nim_sudah_diklaim"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "resolved_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AlumniResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Alumni"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.MetaInfo"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.AlumniRevision": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "alumni_id": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "restored_from": {
+                    "type": "integer"
+                },
+                "snapshot": {
+                    "$ref": "#/definitions/model.Alumni"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AlumniRevisionDiff": {
+            "type": "object",
+            "properties": {
+                "alumni_id": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.AuditChange"
+                    }
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AlumniRevisionDiffResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.AlumniRevisionDiff"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.AlumniRevisionListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AlumniRevision"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.MetaInfo"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.AlumniTrash": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
+                "deleted_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jumlah_pekerjaan": {
+                    "type": "integer"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AlumniTrashResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AlumniTrash"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.MetaInfo"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.AlumniWithSalary": {
+            "type": "object",
+            "properties": {
+                "alumni_id": {
+                    "type": "string"
+                },
+                "angkatan": {
+                    "type": "integer"
+                },
+                "gaji_range": {
+                    "$ref": "#/definitions/model.GajiRange"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "nama_alumni": {
+                    "type": "string"
+                },
+                "nama_perusahaan": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "posisi_jabatan": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AlumniWithYear": {
+            "type": "object",
+            "properties": {
+                "alumni_id": {
+                    "type": "string"
+                },
+                "angkatan": {
+                    "type": "integer"
+                },
+                "gaji_range": {
+                    "$ref": "#/definitions/model.GajiRange"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "nama_alumni": {
+                    "type": "string"
+                },
+                "nama_perusahaan": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "posisi_jabatan": {
+                    "type": "string"
+                },
+                "tahun_lulus": {
+                    "type": "integer"
+                },
+                "tanggal_mulai_kerja": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuditChange": {
+            "type": "object",
+            "properties": {
+                "after": {},
+                "before": {}
+            }
+        },
+        "model.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.AuditChange"
+                    }
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AuditLog"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.MetaInfo"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.BulkItemResult": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BulkTrashRequest": {
+            "type": "object",
+            "properties": {
+                "alumni_id": {
+                    "type": "string"
+                },
+                "deleted_before": {
+                    "type": "string",
+                    "example": "2024-01-31"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "665f1c2e8b3a4d0012345678"
+                    ]
+                }
+            }
+        },
+        "model.BulkTrashResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.BulkTrashResult"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.BulkTrashResult": {
+            "type": "object",
+            "properties": {
+                "berhasil": {
+                    "type": "integer"
+                },
+                "diproses": {
+                    "type": "integer"
+                },
+                "gagal": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BulkItemResult"
+                    }
+                },
+                "sisa": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CareerTimeline": {
+            "type": "object",
+            "properties": {
+                "alumni": {
+                    "$ref": "#/definitions/model.Alumni"
+                },
+                "gaps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TimelineGap"
+                    }
+                },
+                "pekerjaan": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TimelineEntry"
+                    }
+                },
+                "pekerjaan_saat_ini": {
+                    "type": "integer"
+                },
+                "total_masa_kerja_bulan": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CareerTimelineResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.CareerTimeline"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "model.ClaimCodeResponse": {
+            "type": "object",
+            "properties": {
+                "alumni_id": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "K7Q2-M9XD"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ClaimReviewResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AlumniClaimReview"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.MetaInfo"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "model.CreateUserRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "operator@unair.ac.id"
+                },
+                "jurusan": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Teknik Informatika"
+                    ]
+                },
+                "password": {
+                    "type": "string",
+                    "example": "Rahasia123"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "operator_ft"
+                }
+            }
+        },
+        "model.DistribusiItem": {
+            "type": "object",
+            "properties": {
+                "jumlah": {
+                    "type": "integer"
+                },
+                "nilai": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.EmploymentRate": {
+            "type": "object",
+            "properties": {
+                "bekerja": {
+                    "type": "integer"
+                },
+                "kelompok": {
+                    "type": "string"
+                },
+                "persentase": {
+                    "type": "number"
+                },
+                "total_alumni": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ErrorKind": {
+            "type": "string",
+            "enum": [
+                "VALIDATION_ERROR",
+                "UNAUTHORIZED",
+                "FORBIDDEN",
+                "NOT_FOUND",
+                "CONFLICT",
+                "INTERNAL_ERROR"
+            ],
+            "x-enum-varnames": [
+                "ErrKindValidation",
+                "ErrKindUnauthorized",
+                "ErrKindForbidden",
+                "ErrKindNotFound",
+                "ErrKindConflict",
+                "ErrKindInternal"
+            ]
+        },
+        "model.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 401
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FieldError"
+                    }
+                },
+                "error_code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ErrorKind"
+                        }
+                    ],
+                    "example": "UNAUTHORIZED"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Token tidak valid"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "model.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "email"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "format email tidak valid"
+                }
+            }
+        },
+        "model.FileResponse": {
+            "type": "object",
+            "properties": {
+                "file_name": {
+                    "type": "string"
+                },
+                "file_path": {
+                    "type": "string"
+                },
+                "file_size": {
+                    "type": "integer"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "original_name": {
+                    "type": "string"
+                },
+                "uploaded_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.GajiRange": {
+            "type": "object",
+            "properties": {
+                "mata_uang": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "max": {
+                    "type": "integer",
+                    "example": 10000000
+                },
+                "min": {
+                    "type": "integer",
+                    "example": 5000000
+                }
+            }
+        },
+        "model.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.ImportResult"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "model.ImportResult": {
+            "type": "object",
+            "properties": {
+                "baris": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImportRowResult"
+                    }
+                },
+                "dibuat": {
+                    "type": "integer"
+                },
+                "diperbarui": {
+                    "type": "integer"
+                },
+                "ditolak": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "tidak_berubah": {
+                    "type": "integer"
+                },
+                "total_baris": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ImportRowResult": {
+            "type": "object",
+            "properties": {
+                "aksi": {
+                    "description": "dibuat, diperbarui, tidak_berubah, ditolak",
+                    "type": "string",
+                    "example": "dibuat"
+                },
+                "baris": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "perubahan": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string",
+                    "example": "RS256"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string",
+                    "example": "RSA"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string",
+                    "example": "sig"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.JWKSet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.JWK"
+                    }
+                }
+            }
+        },
+        "model.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "type": "integer",
+                    "example": 900
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/model.User"
+                }
+            }
+        },
+        "model.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "all": {
+                    "type": "boolean"
+                },
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.MasaTunggu": {
+            "type": "object",
+            "properties": {
+                "jumlah_alumni": {
+                    "type": "integer"
+                },
+                "median_bulan": {
+                    "type": "number"
+                },
+                "rata_rata_bulan": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.MetaInfo": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "order": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "pages": {
+                    "type": "integer"
+                },
+                "search": {
+                    "type": "string"
+                },
+                "sortBy": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PekerjaanAlumni": {
+            "type": "object",
+            "properties": {
+                "alumni_id": {
+                    "type": "string"
+                },
+                "alumni_id_str": {
+                    "description": "bantu parsing dari JSON",
+                    "type": "string"
+                },
+                "bidang_industri": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "deleted_with_alumni": {
+                    "description": "DeletedWithAlumni menandai pekerjaan yang masuk trash karena alumninya dihapus; hanya\npekerjaan ini yang dipulihkan saat alumninya direstore",
+                    "type": "boolean"
+                },
+                "deskripsi_pekerjaan": {
+                    "type": "string"
+                },
+                "gaji_range": {
+                    "$ref": "#/definitions/model.GajiRange"
+                },
+                "gaji_range_raw": {
+                    "description": "teks asli yang gagal dimigrasi",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_deleted": {
+                    "type": "boolean"
+                },
+                "lokasi_kerja": {
+                    "type": "string"
+                },
+                "nama_perusahaan": {
+                    "type": "string"
+                },
+                "posisi_jabatan": {
+                    "type": "string"
+                },
+                "status_pekerjaan": {
+                    "type": "string"
+                },
+                "tanggal_mulai_kerja": {
+                    "type": "string"
+                },
+                "tanggal_selesai_kerja": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PekerjaanAlumniResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PekerjaanAlumni"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.MetaInfo"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.RefreshRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.RegisterRequest": {
+            "type": "object",
+            "properties": {
+                "angkatan": {
+                    "type": "integer",
+                    "example": 2018
+                },
+                "claim_code": {
+                    "type": "string",
+                    "example": "K7Q2-M9XD"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "budi@mail.com"
+                },
+                "jurusan": {
+                    "type": "string",
+                    "example": "Informatika"
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "nim": {
+                    "type": "string",
+                    "example": "187221001"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "Rahasia123"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "alumni"
+                },
+                "tahun_lulus": {
+                    "type": "integer",
+                    "example": 2022
+                },
+                "username": {
+                    "type": "string",
+                    "example": "budi_santoso"
+                }
+            }
+        },
+        "model.ResolveClaimReviewRequest": {
+            "type": "object",
+            "properties": {
+                "catatan": {
+                    "type": "string",
+                    "example": "Data ganda sudah digabung"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "resolved"
+                }
+            }
+        },
+        "model.SalaryBand": {
+            "type": "object",
+            "properties": {
+                "dari": {
+                    "type": "integer"
+                },
+                "jumlah": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "sampai": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.SingleAlumniResponse": {
+            "type": "object",
+            "properties": {
+                "alumni": {
+                    "$ref": "#/definitions/model.Alumni"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "model.SinglePekerjaanResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "pekerjaan_alumni": {
+                    "$ref": "#/definitions/model.PekerjaanAlumni"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "model.StatistikDashboard": {
+            "type": "object",
+            "properties": {
+                "bidang_industri": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DistribusiItem"
+                    }
                 },
-                "nim": {
-                    "type": "string"
+                "lokasi_kerja": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DistribusiItem"
+                    }
                 },
-                "no_telepon": {
-                    "type": "string"
+                "masa_tunggu": {
+                    "$ref": "#/definitions/model.MasaTunggu"
                 },
-                "tahun_lulus": {
-                    "type": "integer"
+                "per_angkatan": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.EmploymentRate"
+                    }
                 },
-                "updated_at": {
-                    "type": "string"
+                "per_jurusan": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.EmploymentRate"
+                    }
                 },
-                "user_id": {
-                    "type": "string"
+                "rentang_gaji": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SalaryBand"
+                    }
                 }
             }
         },
-        "model.AlumniResponse": {
+        "model.StatistikDashboardResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Alumni"
-                    }
+                    "$ref": "#/definitions/model.StatistikDashboard"
                 },
                 "message": {
                     "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/model.MetaInfo"
-                },
                 "success": {
-                    "type": "boolean"
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "model.ErrorResponse": {
+        "model.SuccessResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 401
-                },
                 "message": {
                     "type": "string",
-                    "example": "Token tidak valid"
+                    "example": "Berhasil"
                 },
                 "success": {
                     "type": "boolean",
-                    "example": false
+                    "example": true
                 }
             }
         },
-        "model.FileResponse": {
+        "model.TimelineEntry": {
             "type": "object",
             "properties": {
-                "file_name": {
+                "alumni_id": {
                     "type": "string"
                 },
-                "file_path": {
+                "alumni_id_str": {
+                    "description": "bantu parsing dari JSON",
                     "type": "string"
                 },
-                "file_size": {
-                    "type": "integer"
+                "bidang_industri": {
+                    "type": "string"
                 },
-                "file_type": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "deleted_with_alumni": {
+                    "description": "DeletedWithAlumni menandai pekerjaan yang masuk trash karena alumninya dihapus; hanya\npekerjaan ini yang dipulihkan saat alumninya direstore",
+                    "type": "boolean"
+                },
+                "deskripsi_pekerjaan": {
+                    "type": "string"
+                },
+                "gaji_range": {
+                    "$ref": "#/definitions/model.GajiRange"
+                },
+                "gaji_range_raw": {
+                    "description": "teks asli yang gagal dimigrasi",
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "original_name": {
+                "is_current": {
+                    "type": "boolean"
+                },
+                "is_deleted": {
+                    "type": "boolean"
+                },
+                "lokasi_kerja": {
                     "type": "string"
                 },
-                "uploaded_at": {
+                "masa_kerja_bulan": {
+                    "type": "integer"
+                },
+                "masa_kerja_hari": {
+                    "type": "integer"
+                },
+                "nama_perusahaan": {
                     "type": "string"
                 },
-                "user_id": {
+                "overlap_dengan": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "posisi_jabatan": {
                     "type": "string"
-                }
-            }
-        },
-        "model.LoginRequest": {
-            "type": "object",
-            "properties": {
-                "password": {
+                },
+                "status_pekerjaan": {
                     "type": "string"
                 },
-                "username": {
+                "tanggal_mulai_kerja": {
+                    "type": "string"
+                },
+                "tanggal_selesai_kerja": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "model.MetaInfo": {
+        "model.TimelineGap": {
             "type": "object",
             "properties": {
-                "limit": {
-                    "type": "integer"
-                },
-                "order": {
+                "dari": {
                     "type": "string"
                 },
-                "page": {
-                    "type": "integer"
-                },
-                "pages": {
+                "hari": {
                     "type": "integer"
                 },
-                "search": {
+                "sampai": {
                     "type": "string"
                 },
-                "sortBy": {
+                "sebelum_pekerjaan": {
                     "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "setelah_pekerjaan": {
+                    "type": "string"
                 }
             }
         },
-        "model.PekerjaanAlumni": {
+        "model.Trash": {
             "type": "object",
             "properties": {
                 "alumni_id": {
                     "type": "string"
                 },
-                "alumni_id_str": {
-                    "description": "bantu parsing dari JSON",
+                "deleted_at": {
                     "type": "string"
                 },
-                "bidang_industri": {
+                "id": {
                     "type": "string"
                 },
-                "created_at": {
+                "is_deleted": {
+                    "type": "boolean"
+                },
+                "nama_alumni": {
                     "type": "string"
                 },
-                "deskripsi_pekerjaan": {
+                "nama_perusahaan": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TrashPurgeCandidate": {
+            "type": "object",
+            "properties": {
+                "alumni_id": {
                     "type": "string"
                 },
-                "gaji_range": {
+                "deleted_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "is_deleted": {
+                "nama_perusahaan": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TrashPurgeMetrics": {
+            "type": "object",
+            "properties": {
+                "enabled": {
                     "type": "boolean"
                 },
-                "lokasi_kerja": {
-                    "type": "string"
+                "failed_runs": {
+                    "type": "integer"
                 },
-                "nama_perusahaan": {
+                "instance": {
                     "type": "string"
                 },
-                "posisi_jabatan": {
+                "interval": {
                     "type": "string"
                 },
-                "status_pekerjaan": {
-                    "type": "string"
+                "last_duration_ms": {
+                    "type": "integer"
                 },
-                "tanggal_mulai_kerja": {
+                "last_error": {
                     "type": "string"
                 },
-                "tanggal_selesai_kerja": {
+                "last_purged": {
+                    "type": "integer"
+                },
+                "last_run_at": {
                     "type": "string"
                 },
-                "updated_at": {
+                "next_run_at": {
                     "type": "string"
+                },
+                "retention_days": {
+                    "type": "integer"
+                },
+                "runs": {
+                    "type": "integer"
+                },
+                "skipped_runs": {
+                    "type": "integer"
+                },
+                "total_purged": {
+                    "type": "integer"
                 }
             }
         },
-        "model.PekerjaanAlumniResponse": {
+        "model.TrashPurgeMetricsResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.PekerjaanAlumni"
-                    }
+                    "$ref": "#/definitions/model.TrashPurgeMetrics"
                 },
                 "message": {
                     "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/model.MetaInfo"
-                },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "model.RegisterRequest": {
+        "model.TrashPurgeReport": {
             "type": "object",
-            "required": [
-                "email",
-                "password",
-                "username"
-            ],
             "properties": {
-                "email": {
+                "cutoff": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TrashPurgeCandidate"
+                    }
                 },
-                "role": {
-                    "type": "string"
+                "retention_days": {
+                    "type": "integer"
                 },
-                "username": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 3
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "model.SingleAlumniResponse": {
+        "model.TrashPurgeReportResponse": {
             "type": "object",
             "properties": {
-                "alumni": {
-                    "$ref": "#/definitions/model.Alumni"
+                "data": {
+                    "$ref": "#/definitions/model.TrashPurgeReport"
                 },
                 "message": {
                     "type": "string"
                 },
                 "success": {
-                    "type": "boolean",
-                    "example": true
+                    "type": "boolean"
                 }
             }
         },
-        "model.SinglePekerjaanResponse": {
+        "model.TrashResponse": {
             "type": "object",
             "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Trash"
+                    }
+                },
                 "message": {
                     "type": "string"
                 },
-                "pekerjaan_alumni": {
-                    "$ref": "#/definitions/model.PekerjaanAlumni"
+                "meta": {
+                    "$ref": "#/definitions/model.MetaInfo"
                 },
                 "success": {
-                    "type": "boolean",
-                    "example": true
+                    "type": "boolean"
                 }
             }
         },
-        "model.Trash": {
+        "model.UpdateMyProfileRequest": {
             "type": "object",
             "properties": {
-                "alumni_id": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "is_deleted": {
-                    "type": "boolean"
+                "alamat": {
+                    "type": "string",
+                    "example": "Jl. Airlangga No. 4, Surabaya"
                 },
-                "nama_alumni": {
-                    "type": "string"
+                "email": {
+                    "type": "string",
+                    "example": "budi@mail.com"
                 },
-                "nama_perusahaan": {
-                    "type": "string"
+                "no_telepon": {
+                    "type": "string",
+                    "example": "081234567890"
                 }
             }
         },
-        "model.TrashResponse": {
+        "model.User": {
             "type": "object",
             "properties": {
-                "data": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jurusan": {
+                    "description": "Jurusan -\u003e jurusan yang boleh diakses user ber-role staff",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Trash"
+                        "type": "string"
                     }
                 },
-                "message": {
+                "password_hash": {
                     "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/model.MetaInfo"
+                "role": {
+                    "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "username": {
+                    "type": "string"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Access token dengan format \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -1,23 +1,39 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 
 	"github.com/noorfarihaf11/clean-arc/config"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"github.com/noorfarihaf11/clean-arc/app/service"
 	"github.com/noorfarihaf11/clean-arc/database"
+	"github.com/noorfarihaf11/clean-arc/middleware"
 	"github.com/noorfarihaf11/clean-arc/routes"
+	"github.com/noorfarihaf11/clean-arc/utils"
 	"github.com/noorfarihaf11/clean-arc/docs" 
 	fiberSwagger  "github.com/swaggo/fiber-swagger" 
 )
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Access token dengan format "Bearer <token>"
 func main() {
 	config.LoadEnv()
 
+	if err := utils.LoadJWTConfig(); err != nil {
+		log.Fatalf("Konfigurasi JWT tidak valid: %v", err)
+	}
+
 	docs.SwaggerInfo.Title = "Clean Architecture API"
 	docs.SwaggerInfo.Description = "Dokumentasi API untuk proyek Clean Architecture (Fiber + MongoDB)"
 	docs.SwaggerInfo.Version = "1.0"
@@ -30,9 +46,73 @@ func main() {
 		log.Fatalf("Gagal konek ke MongoDB: %v", err)
 	}
 
-	app := fiber.New(fiber.Config{
-		BodyLimit: 10 * 1024 * 1024,
-	})
+	converted, failed, err := repository.MigrateGajiRange(db)
+	if err != nil {
+		log.Fatalf("Gagal migrasi gaji_range: %v", err)
+	}
+	if converted > 0 || failed > 0 {
+		log.Printf("Migrasi gaji_range: %d dikonversi, %d gagal di-parse", converted, failed)
+	}
+
+	if err := repository.EnsureUserIndexes(db); err != nil {
+		log.Fatalf("Gagal menyiapkan index users: %v", err)
+	}
+
+	if err := repository.EnsureTokenIndexes(db); err != nil {
+		log.Fatalf("Gagal menyiapkan index token: %v", err)
+	}
+
+	if err := repository.EnsureAuditIndexes(db); err != nil {
+		log.Fatalf("Gagal menyiapkan index audit log: %v", err)
+	}
+
+	if err := repository.EnsureAlumniRevisionIndexes(db); err != nil {
+		log.Fatalf("Gagal menyiapkan index revisi alumni: %v", err)
+	}
+
+	if err := repository.EnsureAlumniIndexes(db); err != nil {
+		log.Fatalf("Gagal menyiapkan index alumni: %v", err)
+	}
+
+	if err := repository.EnsureClaimIndexes(db); err != nil {
+		log.Fatalf("Gagal menyiapkan index klaim alumni: %v", err)
+	}
+
+	if err := middleware.LoadPolicy(db); err != nil {
+		log.Fatalf("Gagal memuat policy RBAC: %v", err)
+	}
+
+	purgeCfg, err := service.TrashPurgeConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Konfigurasi purge trash tidak valid: %v", err)
+	}
+	// ctx selesai saat SIGINT/SIGTERM; server dan worker purge dihentikan dengan rapi
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	purger := service.StartTrashPurger(ctx, db, purgeCfg)
+
+	appConfig := fiber.Config{
+		BodyLimit:    10 * 1024 * 1024,
+		ErrorHandler: middleware.ErrorHandler,
+	}
+	// di belakang reverse proxy, IP klien (dipakai rate limit dan audit log) diambil dari header
+	// proxy, tetapi hanya jika request datang dari alamat proxy yang terdaftar di TRUSTED_PROXIES
+	if proxies := os.Getenv("TRUSTED_PROXIES"); proxies != "" {
+		appConfig.EnableTrustedProxyCheck = true
+		appConfig.EnableIPValidation = true
+		appConfig.ProxyHeader = fiber.HeaderXForwardedFor
+		if header := os.Getenv("PROXY_HEADER"); header != "" {
+			appConfig.ProxyHeader = header
+		}
+		for _, p := range strings.Split(proxies, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				appConfig.TrustedProxies = append(appConfig.TrustedProxies, p)
+			}
+		}
+	}
+
+	app := fiber.New(appConfig)
 
 	app.Use(cors.New())
 	app.Use(logger.New())
@@ -47,6 +127,20 @@ func main() {
 	// Semua route terpusat di sini
 	routes.Routes(app, db)
 
+	go func() {
+		<-ctx.Done()
+		log.Println("Menghentikan server...")
+		if err := app.Shutdown(); err != nil {
+			log.Printf("Gagal menghentikan server: %v", err)
+		}
+	}()
+
 	log.Printf("Server running on port %s 🚀", port)
-	log.Fatal(app.Listen(":" + port))
+	if err := app.Listen(":" + port); err != nil {
+		log.Fatal(err)
+	}
+
+	// tunggu putaran purge yang sedang berjalan selesai sebelum proses keluar
+	purger.Wait()
+	log.Println("Server berhenti")
 }
@@ -3,86 +3,55 @@ package middleware
 import (
 	"strings"
 
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
 	"github.com/noorfarihaf11/clean-arc/utils"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-// Middleware untuk memerlukan login
-func AuthRequired() fiber.Handler { 
+// Middleware untuk memerlukan login. Token yang JTI-nya sudah dicabut (logout/revoke) ditolak.
+func AuthRequired(db *mongo.Database) fiber.Handler { 
     return func(c *fiber.Ctx) error { 
         // Ambil token dari header Authorization 
         authHeader := c.Get("Authorization") 
         if authHeader == "" { 
-            return c.Status(401).JSON(fiber.Map{ 
-                "error": "Token akses diperlukan", 
-            }) 
+            return model.NewUnauthorizedError("Token akses diperlukan") 
         } 
  
         // Extract token dari "Bearer TOKEN" 
         tokenParts := strings.Split(authHeader, " ") 
         if len(tokenParts) != 2 || tokenParts[0] != "Bearer" { 
-            return c.Status(401).JSON(fiber.Map{ 
-                "error": "Format token tidak valid", 
-            })  
+            return model.NewUnauthorizedError("Format token tidak valid")  
         } 
  
         // Validasi token 
         claims, err := utils.ValidateToken(tokenParts[1]) 
         if err != nil { 
-            return c.Status(401).JSON(fiber.Map{ 
-                "error": "Token tidak valid atau expired", 
-            }) 
+            return model.NewUnauthorizedError("Token tidak valid atau expired") 
         } 
+
+        // Cek apakah token sudah dicabut
+        if claims.ID == "" {
+            return model.NewUnauthorizedError("Token tidak valid atau expired")
+        }
+        revoked, err := repository.IsTokenRevoked(db, claims.ID)
+        if err != nil {
+            return model.NewInternalError("Gagal memeriksa status token", err)
+        }
+        if revoked {
+            return model.NewUnauthorizedError("Token sudah dicabut, silakan login kembali")
+        }
  
         // Simpan informasi user di context 
         c.Locals("user_id", claims.UserID) 
         c.Locals("username", claims.Username) 
         c.Locals("role", claims.Role) 
+        c.Locals("jurusan", claims.Jurusan)
+        c.Locals("jti", claims.ID)
+        c.Locals("token_expires_at", claims.ExpiresAt.Time)
  
         return c.Next() 
     } 
-} 
- 
-// Middleware untuk memerlukan role admin 
-func AdminOnly() fiber.Handler { 
-    return func(c *fiber.Ctx) error { 
-        role := c.Locals("role").(string) 
-        if role != "admin" { 
-            return c.Status(403).JSON(fiber.Map{ 
-                "error": "Akses ditolak. Hanya admin yang diizinkan", 
-            }) 
-        } 
-        return c.Next() 
-    } 
-}
-func UserAccessMiddleware() fiber.Handler {
-	return func(c *fiber.Ctx) error {
-		role := c.Locals("role").(string)
-		userIDFromToken := c.Locals("user_id")
-
-		userIDParam := c.Params("user_id")
-
-		// Kalau admin, langsung lanjut
-		if role == "admin" {
-			return c.Next()
-		}
-
-		// Kalau user biasa, pastikan user_id di URL sama dengan token JWT
-		userObjectID, ok := userIDFromToken.(primitive.ObjectID)
-		if !ok {
-			return c.Status(500).JSON(fiber.Map{
-				"error": "Invalid user ID type in token",
-			})
-		}
-
-		if userObjectID.Hex() != userIDParam {
-			return c.Status(403).JSON(fiber.Map{
-				"error": "Akses ditolak. Anda hanya bisa upload untuk diri sendiri",
-			})
-		}
-
-		return c.Next()
-	}
 }
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+)
+
+// errorStatus memetakan jenis error domain ke status HTTP
+var errorStatus = map[model.ErrorKind]int{
+	model.ErrKindValidation:   fiber.StatusBadRequest,
+	model.ErrKindUnauthorized: fiber.StatusUnauthorized,
+	model.ErrKindForbidden:    fiber.StatusForbidden,
+	model.ErrKindNotFound:     fiber.StatusNotFound,
+	model.ErrKindConflict:     fiber.StatusConflict,
+	model.ErrKindInternal:     fiber.StatusInternalServerError,
+}
+
+// ErrorHandler dipasang sebagai fiber.Config.ErrorHandler. Semua error yang dikembalikan
+// handler dan middleware diubah menjadi model.ErrorResponse: model.AppError sesuai jenisnya,
+// *fiber.Error sesuai status bawaan Fiber, dan error lain menjadi 500 tanpa membocorkan
+// pesan aslinya ke client.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	resp := model.ErrorResponse{Success: false}
+
+	var appErr *model.AppError
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &appErr):
+		resp.Code = errorStatus[appErr.Kind]
+		if resp.Code == 0 {
+			resp.Code = fiber.StatusInternalServerError
+		}
+		resp.ErrorCode = appErr.Kind
+		resp.Message = appErr.Message
+		resp.Details = appErr.Fields
+	case errors.As(err, &fiberErr):
+		resp.Code = fiberErr.Code
+		resp.ErrorCode = statusErrorKind(fiberErr.Code)
+		resp.Message = fiberErr.Message
+	default:
+		resp.Code = fiber.StatusInternalServerError
+		resp.ErrorCode = model.ErrKindInternal
+		resp.Message = "Terjadi kesalahan pada server"
+	}
+
+	if resp.Code >= fiber.StatusInternalServerError {
+		log.Printf("%s %s: %v", c.Method(), c.Path(), err)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// statusErrorKind menurunkan kode error dari status HTTP untuk *fiber.Error, misalnya
+// 405 menjadi METHOD_NOT_ALLOWED
+func statusErrorKind(status int) model.ErrorKind {
+	for kind, s := range errorStatus {
+		if s == status {
+			return kind
+		}
+	}
+	name := strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(utils.StatusMessage(status)))
+	if name == "" {
+		return model.ErrKindInternal
+	}
+	return model.ErrorKind(name)
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    model.ErrorKind
+		message string
+		details int
+	}{
+		{
+			name:    "validasi dengan detail field",
+			err:     model.NewValidationError("Data user tidak valid", model.FieldError{Field: "email", Message: "email tidak valid"}),
+			status:  400,
+			code:    model.ErrKindValidation,
+			message: "Data user tidak valid",
+			details: 1,
+		},
+		{"not found", model.NewNotFoundError("Alumni tidak ditemukan"), 404, model.ErrKindNotFound, "Alumni tidak ditemukan", 0},
+		{"forbidden", model.NewForbiddenError("Akses ditolak"), 403, model.ErrKindForbidden, "Akses ditolak", 0},
+		{"conflict", model.NewConflictError("NIM sudah terdaftar"), 409, model.ErrKindConflict, "NIM sudah terdaftar", 0},
+		{"app error terbungkus", model.WrapError(model.NewNotFoundError("File not found"), "Gagal"), 404, model.ErrKindNotFound, "File not found", 0},
+		{"internal tidak membocorkan penyebab", model.WrapError(errors.New("koneksi putus"), "Gagal mengambil data"), 500, model.ErrKindInternal, "Gagal mengambil data", 0},
+		{"error biasa", errors.New("koneksi putus"), 500, model.ErrKindInternal, "Terjadi kesalahan pada server", 0},
+		{"sentinel file", repository.ErrFileNotFound, 404, model.ErrKindNotFound, "File not found", 0},
+		{"sentinel trash terbungkus", model.WrapError(repository.ErrJobNotInTrash, "Gagal restore pekerjaan"), 404, model.ErrKindNotFound, "Pekerjaan tidak ditemukan di trash", 0},
+		{"error driver", model.WrapError(mongo.CommandError{Code: 6, Message: "koneksi putus ke 10.0.0.5"}, "Gagal restore pekerjaan"), 500, model.ErrKindInternal, "Gagal restore pekerjaan", 0},
+		{"fiber error", fiber.ErrMethodNotAllowed, 405, "METHOD_NOT_ALLOWED", "Method Not Allowed", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+			app.Get("/", func(c *fiber.Ctx) error { return tt.err })
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			raw, _ := io.ReadAll(resp.Body)
+			var body model.ErrorResponse
+			if err := json.Unmarshal(raw, &body); err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(raw), "koneksi putus") {
+				t.Errorf("penyebab error bocor ke client: %s", raw)
+			}
+
+			if resp.StatusCode != tt.status || body.Code != tt.status {
+				t.Errorf("status = %d (code %d), want %d", resp.StatusCode, body.Code, tt.status)
+			}
+			if body.Success || body.ErrorCode != tt.code || body.Message != tt.message || len(body.Details) != tt.details {
+				t.Errorf("unexpected body: %+v", body)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+)
+
+// RateLimit membatasi paling banyak max request per key dalam window. key nil berarti per IP
+// (c.IP(), yang mengikuti ProxyHeader jika request datang dari proxy tepercaya). Request yang
+// melebihi batas ditolak dengan 429 dan message lewat ErrorHandler.
+func RateLimit(max int, window time.Duration, key func(*fiber.Ctx) string, message string) fiber.Handler {
+	cfg := limiter.Config{
+		Max:        max,
+		Expiration: window,
+		LimitReached: func(c *fiber.Ctx) error {
+			return fiber.NewError(fiber.StatusTooManyRequests, message)
+		},
+	}
+	if key != nil {
+		cfg.KeyGenerator = key
+	}
+	return limiter.New(cfg)
+}
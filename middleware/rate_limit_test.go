@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRateLimit(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Post("/", RateLimit(2, time.Minute, nil, "Terlalu banyak percobaan"), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	for i, want := range []int{200, 200, 429} {
+		resp, err := app.Test(httptest.NewRequest("POST", "/", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != want {
+			t.Errorf("request %d: status = %d, want %d", i+1, resp.StatusCode, want)
+		}
+	}
+}
+
+func TestRateLimit_Key(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	key := func(c *fiber.Ctx) string { return c.Get("X-NIM") }
+	app.Post("/", RateLimit(1, time.Minute, key, "Terlalu banyak percobaan"), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	// IP sama, tetapi setiap NIM punya kuota sendiri
+	for i, tt := range []struct {
+		nim  string
+		want int
+	}{
+		{"187221001", 200},
+		{"187221002", 200},
+		{"187221001", 429},
+	} {
+		req := httptest.NewRequest("POST", "/", nil)
+		req.Header.Set("X-NIM", tt.nim)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != tt.want {
+			t.Errorf("request %d (%s): status = %d, want %d", i+1, tt.nim, resp.StatusCode, tt.want)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Policy memetakan role ke permission yang dimilikinya
+type Policy struct {
+	roles map[string]map[model.Permission]bool
+}
+
+var (
+	policyMu      sync.RWMutex
+	currentPolicy = DefaultPolicy()
+)
+
+// defaultRoles dipakai jika RBAC_POLICY_FILE tidak diisi dan collection roles kosong
+var defaultRoles = []model.RoleDefinition{
+	{Name: model.RoleAdmin, Permissions: []string{model.PermissionWildcard}},
+	{Name: model.RoleAlumni, Permissions: []string{
+		string(model.PermAlumniRead),
+		string(model.PermJobRead),
+		string(model.PermJobReadAlumni.Own()),
+		string(model.PermJobCreate.Own()),
+		string(model.PermJobUpdate.Own()),
+		string(model.PermJobDelete.Own()),
+		string(model.PermJobTrash.Own()),
+		string(model.PermJobRestore.Own()),
+		string(model.PermJobHardDelete.Own()),
+		string(model.PermFileRead.Own()),
+		string(model.PermFileUpload.Own()),
+		string(model.PermFileDelete.Own()),
+	}},
+	{Name: model.RoleStaff, Permissions: []string{
+		string(model.PermAlumniRead.InJurusan()),
+		string(model.PermAlumniUpdate.InJurusan()),
+		string(model.PermAlumniTimeline.InJurusan()),
+		string(model.PermAlumniHistory.InJurusan()),
+		string(model.PermAlumniRollback.InJurusan()),
+		string(model.PermJobRead.InJurusan()),
+		string(model.PermJobReadAlumni.InJurusan()),
+		string(model.PermJobCreate.InJurusan()),
+		string(model.PermJobUpdate.InJurusan()),
+		string(model.PermJobDelete.InJurusan()),
+		string(model.PermJobTrash.InJurusan()),
+		string(model.PermJobRestore.InJurusan()),
+		string(model.PermFileRead.InJurusan()),
+	}},
+}
+
+// DefaultPolicy mengembalikan policy bawaan: admin semua permission, alumni hanya data miliknya,
+// dan staff membaca serta mengelola alumni dan pekerjaan pada jurusannya
+func DefaultPolicy() *Policy {
+	p, err := NewPolicy(defaultRoles)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// NewPolicy menyusun policy dari daftar role. Permission yang tidak dikenal, atau akhiran
+// cakupan (":own", ":jurusan") yang tidak didukung handler permission tersebut, ditolak agar
+// salah ketik pada konfigurasi tidak diam-diam mencabut atau memberi akses.
+func NewPolicy(roles []model.RoleDefinition) (*Policy, error) {
+	p := &Policy{roles: map[string]map[model.Permission]bool{}}
+
+	for _, role := range roles {
+		name := strings.ToLower(strings.TrimSpace(role.Name))
+		if name == "" {
+			return nil, fmt.Errorf("nama role wajib diisi")
+		}
+		if _, exists := p.roles[name]; exists {
+			return nil, fmt.Errorf("role %q didefinisikan lebih dari sekali", name)
+		}
+
+		perms := map[model.Permission]bool{}
+		for _, raw := range role.Permissions {
+			perm := model.Permission(strings.TrimSpace(raw))
+			if perm == model.PermissionWildcard {
+				perms[perm] = true
+				continue
+			}
+
+			base, scope := perm.SplitScope()
+			scopes, known := model.KnownPermissions[base]
+			if !known {
+				return nil, fmt.Errorf("role %q: permission %q tidak dikenal", name, perm)
+			}
+			if scope != model.ScopeAll && !slices.Contains(scopes, scope) {
+				return nil, fmt.Errorf("role %q: permission %q tidak bisa dibatasi dengan cakupan %s", name, base, scope)
+			}
+			perms[perm] = true
+		}
+		p.roles[name] = perms
+	}
+
+	return p, nil
+}
+
+// Scope mengembalikan cakupan akses role untuk permission. Jika role memiliki beberapa
+// versi permission, cakupan terluas yang dipakai: ScopeAll, lalu ScopeJurusan, lalu
+// ScopeOwn. false berarti role tidak punya akses.
+func (p *Policy) Scope(role string, perm model.Permission) (model.PermissionScope, bool) {
+	perms := p.roles[strings.ToLower(role)]
+	switch {
+	case perms[model.PermissionWildcard], perms[perm]:
+		return model.ScopeAll, true
+	case perms[perm.InJurusan()]:
+		return model.ScopeJurusan, true
+	case perms[perm.Own()]:
+		return model.ScopeOwn, true
+	}
+	return "", false
+}
+
+// Can memeriksa apakah role memiliki permission dalam cakupan apa pun
+func (p *Policy) Can(role string, perm model.Permission) bool {
+	_, ok := p.Scope(role, perm)
+	return ok
+}
+
+// SetPolicy mengganti policy yang dipakai middleware Require
+func SetPolicy(p *Policy) {
+	policyMu.Lock()
+	defer policyMu.Unlock()
+	currentPolicy = p
+}
+
+// CurrentPolicy mengembalikan policy yang sedang aktif
+func CurrentPolicy() *Policy {
+	policyMu.RLock()
+	defer policyMu.RUnlock()
+	return currentPolicy
+}
+
+// LoadPolicy memuat policy dengan urutan: file JSON di RBAC_POLICY_FILE, lalu collection
+// roles, lalu policy bawaan jika keduanya kosong.
+func LoadPolicy(db *mongo.Database) error {
+	roles, source, err := loadRoleDefinitions(db)
+	if err != nil {
+		return err
+	}
+	if len(roles) == 0 {
+		SetPolicy(DefaultPolicy())
+		return nil
+	}
+
+	p, err := NewPolicy(roles)
+	if err != nil {
+		return fmt.Errorf("policy dari %s tidak valid: %v", source, err)
+	}
+	SetPolicy(p)
+	return nil
+}
+
+func loadRoleDefinitions(db *mongo.Database) ([]model.RoleDefinition, string, error) {
+	if path := os.Getenv("RBAC_POLICY_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, path, fmt.Errorf("gagal membaca RBAC_POLICY_FILE: %v", err)
+		}
+		var file model.RBACPolicyFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, path, fmt.Errorf("RBAC_POLICY_FILE bukan JSON yang valid: %v", err)
+		}
+		return file.Roles, path, nil
+	}
+
+	roles, err := repository.GetRoleDefinitions(db)
+	if err != nil {
+		return nil, "collection roles", fmt.Errorf("gagal mengambil collection roles: %v", err)
+	}
+	return roles, "collection roles", nil
+}
+
+// Require memastikan role pemilik token memiliki permission. Cakupan akses disimpan di
+// locals "permission_scope" (model.ScopeAll, model.ScopeJurusan, atau model.ScopeOwn)
+// untuk dipakai handler dalam membatasi data. Harus dipasang setelah AuthRequired.
+func Require(perm model.Permission) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("role").(string)
+		scope, ok := CurrentPolicy().Scope(role, perm)
+		if !ok {
+			return model.NewForbiddenError("Akses ditolak. Permission " + string(perm) + " diperlukan")
+		}
+
+		c.Locals("permission_scope", scope)
+		return c.Next()
+	}
+}
+
+// RequireSelf seperti Require, tetapi untuk cakupan selain ScopeAll parameter URL param
+// harus sama dengan user_id pemilik token
+func RequireSelf(perm model.Permission, param string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("role").(string)
+		scope, ok := CurrentPolicy().Scope(role, perm)
+		if !ok {
+			return model.NewForbiddenError("Akses ditolak. Permission " + string(perm) + " diperlukan")
+		}
+
+		if scope != model.ScopeAll {
+			userID, _ := c.Locals("user_id").(primitive.ObjectID)
+			if userID.IsZero() || userID.Hex() != c.Params(param) {
+				return model.NewForbiddenError("Akses ditolak. Anda hanya bisa mengakses data milik sendiri")
+			}
+		}
+
+		c.Locals("permission_scope", scope)
+		return c.Next()
+	}
+}
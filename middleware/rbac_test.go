@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDefaultPolicy(t *testing.T) {
+	p := DefaultPolicy()
+
+	if scope, ok := p.Scope(model.RoleAdmin, model.PermJobHardDelete); !ok || scope != model.ScopeAll {
+		t.Errorf("admin should have job:hard_delete, got %q %v", scope, ok)
+	}
+	if scope, ok := p.Scope(model.RoleAlumni, model.PermJobHardDelete); !ok || scope != model.ScopeOwn {
+		t.Errorf("alumni should have job:hard_delete:own, got %q %v", scope, ok)
+	}
+	if scope, ok := p.Scope(model.RoleAlumni, model.PermJobReadAlumni); !ok || scope != model.ScopeOwn {
+		t.Errorf("alumni should only have job:read_alumni:own, got %q %v", scope, ok)
+	}
+	if p.Can(model.RoleAlumni, model.PermAlumniUpdate) {
+		t.Error("alumni must not have alumni:update")
+	}
+	if p.Can(model.RoleAlumni, model.PermAlumniCreate) {
+		t.Error("alumni must not create profiles; they come from registration or a NIM claim")
+	}
+	if scope, ok := p.Scope(model.RoleStaff, model.PermAlumniRead); !ok || scope != model.ScopeJurusan {
+		t.Errorf("staff should have alumni:read:jurusan, got %q %v", scope, ok)
+	}
+	if p.Can(model.RoleStaff, model.PermJobHardDelete) || p.Can(model.RoleStaff, model.PermAlumniExport) {
+		t.Error("staff must not hard delete jobs or export alumni")
+	}
+	if p.Can(model.RoleStaff, model.PermAlumniRestore) || p.Can(model.RoleStaff, model.PermAlumniPurge) {
+		t.Error("staff must not restore or purge alumni from trash")
+	}
+	if p.Can("tamu", model.PermAlumniRead) {
+		t.Error("unknown role must not have any permission")
+	}
+}
+
+func TestNewPolicy_RejectsInvalidPermissions(t *testing.T) {
+	tests := []model.RoleDefinition{
+		{Name: "staff", Permissions: []string{"alumni:reed"}},
+		{Name: "staff", Permissions: []string{"alumni:delete:own"}},
+		{Name: "staff", Permissions: []string{"alumni:export:jurusan"}},
+		{Name: "staff", Permissions: []string{"file:upload:jurusan"}},
+		{Name: "", Permissions: []string{"alumni:read"}},
+	}
+	for _, role := range tests {
+		if _, err := NewPolicy([]model.RoleDefinition{role}); err == nil {
+			t.Errorf("expected error for %+v", role)
+		}
+	}
+
+	dup := []model.RoleDefinition{{Name: "staff"}, {Name: "Staff"}}
+	if _, err := NewPolicy(dup); err == nil {
+		t.Error("expected duplicate role to be rejected")
+	}
+}
+
+func testApp(role string, userID primitive.ObjectID, guard fiber.Handler, path string) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("role", role)
+		c.Locals("user_id", userID)
+		return c.Next()
+	})
+	app.Get(path, guard, func(c *fiber.Ctx) error {
+		return c.SendString(string(c.Locals("permission_scope").(model.PermissionScope)))
+	})
+	return app
+}
+
+func TestRequire(t *testing.T) {
+	p, err := NewPolicy([]model.RoleDefinition{
+		{Name: "staff", Permissions: []string{"alumni:read", "file:read:own"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetPolicy(p)
+	defer SetPolicy(DefaultPolicy())
+
+	tests := []struct {
+		perm   model.Permission
+		status int
+		body   string
+	}{
+		{model.PermAlumniRead, 200, "all"},
+		{model.PermFileRead, 200, "own"},
+		{model.PermAlumniUpdate, 403, ""},
+	}
+	for _, tt := range tests {
+		app := testApp("staff", primitive.NewObjectID(), Require(tt.perm), "/")
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.perm, resp.StatusCode, tt.status)
+		}
+		if tt.status == 200 && string(body) != tt.body {
+			t.Errorf("%s: scope = %q, want %q", tt.perm, body, tt.body)
+		}
+	}
+}
+
+func TestRequireSelf(t *testing.T) {
+	userID := primitive.NewObjectID()
+	guard := RequireSelf(model.PermFileUpload, "user_id")
+
+	app := testApp(model.RoleAlumni, userID, guard, "/:user_id")
+	resp, _ := app.Test(httptest.NewRequest("GET", "/"+userID.Hex(), nil))
+	if resp.StatusCode != 200 {
+		t.Errorf("owner upload: status = %d, want 200", resp.StatusCode)
+	}
+	resp, _ = app.Test(httptest.NewRequest("GET", "/"+primitive.NewObjectID().Hex(), nil))
+	if resp.StatusCode != 403 {
+		t.Errorf("other user upload: status = %d, want 403", resp.StatusCode)
+	}
+
+	admin := testApp(model.RoleAdmin, userID, guard, "/:user_id")
+	resp, _ = admin.Test(httptest.NewRequest("GET", "/"+primitive.NewObjectID().Hex(), nil))
+	if resp.StatusCode != 200 {
+		t.Errorf("admin upload: status = %d, want 200", resp.StatusCode)
+	}
+}
@@ -4,14 +4,15 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
 	"github.com/noorfarihaf11/clean-arc/app/service"
 	"github.com/noorfarihaf11/clean-arc/middleware"
 )
 
 func AlumniRoutes(api fiber.Router, db *mongo.Database) {
-	alumni := api.Group("/unair/alumni", middleware.AuthRequired())
+	alumni := api.Group("/unair/alumni", middleware.AuthRequired(db))
 
-	alumni.Get("/",  func(c *fiber.Ctx) error {
+	alumni.Get("/", middleware.Require(model.PermAlumniRead), func(c *fiber.Ctx) error {
 		return service.GetAllAlumniService(c, db)
 	})
 
@@ -19,27 +20,75 @@ func AlumniRoutes(api fiber.Router, db *mongo.Database) {
 	// 	return service.GetAlumniByIDService(c, db)
 	// })
 
-	alumni.Post("/", func(c *fiber.Ctx) error {
+	alumni.Post("/", middleware.Require(model.PermAlumniCreate), func(c *fiber.Ctx) error {
 		return service.CreateAlumniService(c, db)
 	})
 
-	alumni.Put("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+	alumni.Get("/export", middleware.Require(model.PermAlumniExport), func(c *fiber.Ctx) error {
+		return service.ExportAlumniService(c, db)
+	})
+
+	alumni.Post("/import", middleware.Require(model.PermAlumniImport), func(c *fiber.Ctx) error {
+		return service.ImportAlumniService(c, db)
+	})
+
+	alumni.Get("/claim-reviews", middleware.Require(model.PermAlumniClaim), func(c *fiber.Ctx) error {
+		return service.GetClaimReviewsService(c, db)
+	})
+
+	alumni.Put("/claim-reviews/:id", middleware.Require(model.PermAlumniClaim), func(c *fiber.Ctx) error {
+		return service.ResolveClaimReviewService(c, db)
+	})
+
+	alumni.Post("/:id/claim-code", middleware.Require(model.PermAlumniClaim), func(c *fiber.Ctx) error {
+		return service.IssueClaimCodeService(c, db)
+	})
+
+	alumni.Put("/:id", middleware.Require(model.PermAlumniUpdate), func(c *fiber.Ctx) error {
 		return service.UpdateAlumniService(c, db)
 	})
 
-	alumni.Delete("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+	alumni.Delete("/:id", middleware.Require(model.PermAlumniDelete), func(c *fiber.Ctx) error {
 		return service.DeleteAlumniService(c, db)
 	})
 
-	// alumni.Get("/filter/high-salary", func(c *fiber.Ctx) error {
-	// 	return service.GetAlumniBySalaryService(c, db)
-	// })
+	alumni.Get("/filter/trash", middleware.Require(model.PermAlumniTrash), func(c *fiber.Ctx) error {
+		return service.GetAlumniTrashService(c, db)
+	})
 
-	// alumni.Get("/filter/year", func(c *fiber.Ctx) error {
-	// 	return service.GetAlumniByYearService(c, db)
-	// })
+	alumni.Put("/filter/restore/:id", middleware.Require(model.PermAlumniRestore), func(c *fiber.Ctx) error {
+		return service.RestoreAlumniService(c, db)
+	})
 
-	// alumni.Get("/filter/yearjob", func(c *fiber.Ctx) error {
-	// 	return service.GetAlumniWithYearService(c, db)
-	// })
+	alumni.Delete("/filter/delete/:id", middleware.Require(model.PermAlumniPurge), func(c *fiber.Ctx) error {
+		return service.PurgeAlumniService(c, db)
+	})
+
+	alumni.Get("/filter/high-salary", middleware.Require(model.PermAlumniRead), func(c *fiber.Ctx) error {
+		return service.GetAlumniBySalaryService(c, db)
+	})
+
+	alumni.Get("/filter/year", middleware.Require(model.PermAlumniRead), func(c *fiber.Ctx) error {
+		return service.GetAlumniByYearService(c, db)
+	})
+
+	alumni.Get("/filter/yearjob", middleware.Require(model.PermAlumniRead), func(c *fiber.Ctx) error {
+		return service.GetAlumniWithYearService(c, db)
+	})
+
+	alumni.Get("/:id/revisions", middleware.Require(model.PermAlumniHistory), func(c *fiber.Ctx) error {
+		return service.GetAlumniRevisionsService(c, db)
+	})
+
+	alumni.Get("/:id/revisions/diff", middleware.Require(model.PermAlumniHistory), func(c *fiber.Ctx) error {
+		return service.DiffAlumniRevisionsService(c, db)
+	})
+
+	alumni.Post("/:id/revisions/:version/rollback", middleware.Require(model.PermAlumniRollback), func(c *fiber.Ctx) error {
+		return service.RollbackAlumniService(c, db)
+	})
+
+	alumni.Get("/:id/timeline", middleware.Require(model.PermAlumniTimeline), func(c *fiber.Ctx) error {
+		return service.GetCareerTimelineService(c, db)
+	})
 }
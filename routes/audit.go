@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/service"
+	"github.com/noorfarihaf11/clean-arc/middleware"
+)
+
+func AuditRoutes(api fiber.Router, db *mongo.Database) {
+	audit := api.Group("/api/audit-logs", middleware.AuthRequired(db), middleware.Require(model.PermAuditRead))
+
+	audit.Get("/", func(c *fiber.Ctx) error {
+		return service.GetAuditLogsService(c, db)
+	})
+}
@@ -1,11 +1,15 @@
 package routes
 
 import (
+	"strings"
+	"time"
+
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/noorfarihaf11/clean-arc/app/model"
 	"github.com/noorfarihaf11/clean-arc/app/service"
+	"github.com/noorfarihaf11/clean-arc/middleware"
 )
 
 func AuthRoutes(api fiber.Router, db *mongo.Database) {
@@ -13,29 +17,59 @@ func AuthRoutes(api fiber.Router, db *mongo.Database) {
 	api.Post("/api/login", func(c *fiber.Ctx) error {
 		var req model.LoginRequest
 		if err := c.BodyParser(&req); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Request body tidak valid",
-			})
+			return model.NewValidationError("Request body tidak valid")
 		}
 
-		token, user, err := service.LoginService(db, req)
+		resp, err := service.LoginService(db, req)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": err.Error(),
-			})
+			return err
 		}
 
 		return c.JSON(fiber.Map{
 			"success": true,
 			"message": "Login berhasil",
-			"data": fiber.Map{
-				"user":  user,
-				"token": token,
-			},
+			"data":    resp,
 		})
 	})
 
-	api.Post("/api/register", func(c *fiber.Ctx) error {
-		return service.RegisterService(c, db)
+	// registrasi tidak butuh login dan bisa mencatat klaim NIM untuk admin. Batas per IP dibuat
+	// longgar karena banyak pendaftar bisa berbagi satu IP (NAT kampus); percobaan klaim dibatasi
+	// per NIM yang didaftarkan.
+	api.Post("/api/register",
+		middleware.RateLimit(30, 15*time.Minute, nil, "Terlalu banyak percobaan registrasi dari alamat ini, coba lagi nanti"),
+		middleware.RateLimit(5, 15*time.Minute, registerNIMKey, "Terlalu banyak percobaan registrasi untuk NIM ini, coba lagi nanti"),
+		func(c *fiber.Ctx) error {
+			return service.RegisterService(c, db)
+		},
+	)
+
+	api.Get("/.well-known/jwks.json", service.JWKSService)
+
+	api.Post("/api/refresh", func(c *fiber.Ctx) error {
+		return service.RefreshService(c, db)
+	})
+
+	api.Post("/api/logout", middleware.AuthRequired(db), func(c *fiber.Ctx) error {
+		return service.LogoutService(c, db)
+	})
+
+	api.Post("/api/users", middleware.AuthRequired(db), middleware.Require(model.PermUserCreate), func(c *fiber.Ctx) error {
+		return service.CreateUserService(c, db)
+	})
+
+	api.Post("/api/users/:id/revoke-sessions", middleware.AuthRequired(db), middleware.Require(model.PermUserRevoke), func(c *fiber.Ctx) error {
+		return service.RevokeUserSessionsService(c, db)
 	})
 }
+
+// registerNIMKey memakai NIM pada body registrasi sebagai kunci rate limit. Body tanpa NIM
+// (yang pasti ditolak validasi) dihitung per IP.
+func registerNIMKey(c *fiber.Ctx) string {
+	var req model.RegisterRequest
+	if err := c.BodyParser(&req); err == nil {
+		if nim := strings.TrimSpace(req.NIM); nim != "" {
+			return "nim:" + nim
+		}
+	}
+	return "ip:" + c.IP()
+}
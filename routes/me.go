@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/repository"
+	"github.com/noorfarihaf11/clean-arc/app/service"
+	"github.com/noorfarihaf11/clean-arc/middleware"
+)
+
+// MeRoutes berisi endpoint self-service untuk user yang login; data dicari dari user_id di token
+func MeRoutes(api fiber.Router, db *mongo.Database) {
+	me := api.Group("/api/me", middleware.AuthRequired(db))
+
+	fileService := service.NewFileService(repository.NewFileRepository(db), repository.NewAuditRepository(db), "./uploads")
+
+	me.Get("/", func(c *fiber.Ctx) error {
+		return service.GetMyProfileService(c, db)
+	})
+
+	me.Put("/", func(c *fiber.Ctx) error {
+		return service.UpdateMyProfileService(c, db)
+	})
+
+	me.Get("/pekerjaan", func(c *fiber.Ctx) error {
+		return service.GetMyJobsService(c, db)
+	})
+
+	me.Get("/files", func(c *fiber.Ctx) error {
+		return fileService.GetMyFiles(c)
+	})
+}
@@ -4,51 +4,78 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
 	"github.com/noorfarihaf11/clean-arc/app/service"
 	"github.com/noorfarihaf11/clean-arc/middleware"
 )
 
 func JobRoutes(api fiber.Router, db *mongo.Database) {
-	job := api.Group("/unair/pekerjaan", middleware.AuthRequired())
+	job := api.Group("/unair/pekerjaan", middleware.AuthRequired(db))
 
-	job.Get("/", func(c *fiber.Ctx) error {
+	job.Get("/", middleware.Require(model.PermJobRead), func(c *fiber.Ctx) error {
 		return service.GetAllJobService(c, db)
 	})
 
-	job.Get("/:id", func(c *fiber.Ctx) error {
+	job.Get("/export", middleware.Require(model.PermJobExport), func(c *fiber.Ctx) error {
+		return service.ExportJobService(c, db)
+	})
+
+	job.Get("/:id", middleware.Require(model.PermJobRead), func(c *fiber.Ctx) error {
 		return service.GetJobByIDService(c, db)
 	})
 
-	job.Get("/alumni/:alumni_id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+	// daftar pekerjaan per alumni memakai permission sendiri agar alumni hanya bisa melihat
+	// pekerjaan miliknya (job:read_alumni:own), walaupun job:read-nya tidak dibatasi
+	job.Get("/alumni/:alumni_id", middleware.Require(model.PermJobReadAlumni), func(c *fiber.Ctx) error {
 		return service.GetJobsByAlumniIDService(c, db)
 	})
 
-	job.Post("/",  middleware.AdminOnly(), func(c *fiber.Ctx) error {
+	job.Post("/", middleware.Require(model.PermJobCreate), func(c *fiber.Ctx) error {
 		return service.CreateJobService(c, db)
 	})
 
-	job.Put("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+	job.Put("/:id", middleware.Require(model.PermJobUpdate), func(c *fiber.Ctx) error {
 		return service.UpdateJobService(c, db)
 	})
 
-	job.Put("/filter/trash/:id", func(c *fiber.Ctx) error {
+	job.Put("/filter/trash/:id", middleware.Require(model.PermJobDelete), func(c *fiber.Ctx) error {
 		return service.DeleteJobService(c, db)
 	})
 
+	job.Post("/filter/trash/bulk", middleware.Require(model.PermJobDelete), func(c *fiber.Ctx) error {
+		return service.BulkDeleteJobService(c, db)
+	})
+
 	// job.Get("/filter/jobmoretwo/:id", func(c *fiber.Ctx) error {
 	// 	return service.GetTotalJobAlumniService(c, db)
 	// })
 
-	job.Get("/filter/trash", func(c *fiber.Ctx) error {
+	job.Get("/filter/trash", middleware.Require(model.PermJobTrash), func(c *fiber.Ctx) error {
 		return service.GetTrashService(c, db)
 	})
 
-	job.Put("/filter/restore/:id", func(c *fiber.Ctx) error {
+	job.Get("/filter/trash/purge-report", middleware.Require(model.PermJobPurge), func(c *fiber.Ctx) error {
+		return service.GetTrashPurgeReportService(c, db)
+	})
+
+	job.Get("/filter/trash/purge-metrics", middleware.Require(model.PermJobPurge), func(c *fiber.Ctx) error {
+		return service.GetTrashPurgeMetricsService(c)
+	})
+
+	job.Put("/filter/restore/:id", middleware.Require(model.PermJobRestore), func(c *fiber.Ctx) error {
 		return service.RestoreService(c, db)
 	})
 
-	job.Delete("/filter/delete/:id", func(c *fiber.Ctx) error {
+	job.Post("/filter/restore/bulk", middleware.Require(model.PermJobRestore), func(c *fiber.Ctx) error {
+		return service.BulkRestoreService(c, db)
+	})
+
+	job.Delete("/filter/delete/:id", middleware.Require(model.PermJobHardDelete), func(c *fiber.Ctx) error {
 		return service.HardDeleteService(c, db)
 	})
 
+	job.Post("/filter/delete/bulk", middleware.Require(model.PermJobHardDelete), func(c *fiber.Ctx) error {
+		return service.BulkHardDeleteService(c, db)
+	})
+
 }
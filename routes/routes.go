@@ -13,4 +13,7 @@ func Routes(app *fiber.App, db *mongo.Database) {
 	AlumniRoutes(api, db)
 	JobRoutes(api, db)
 	FileRoutes(api, db)
+	StatistikRoutes(api, db)
+	MeRoutes(api, db)
+	AuditRoutes(api, db)
 }
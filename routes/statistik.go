@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"github.com/noorfarihaf11/clean-arc/app/service"
+	"github.com/noorfarihaf11/clean-arc/middleware"
+)
+
+func StatistikRoutes(api fiber.Router, db *mongo.Database) {
+	statistik := api.Group("/unair/statistik", middleware.AuthRequired(db), middleware.Require(model.PermStatistikRead))
+
+	statistik.Get("/", func(c *fiber.Ctx) error {
+		return service.GetStatistikDashboardService(c, db)
+	})
+
+	statistik.Get("/employment/:group", func(c *fiber.Ctx) error {
+		return service.GetEmploymentRateService(c, db)
+	})
+
+	statistik.Get("/masa-tunggu", func(c *fiber.Ctx) error {
+		return service.GetMasaTungguService(c, db)
+	})
+
+	statistik.Get("/distribusi/:field", func(c *fiber.Ctx) error {
+		return service.GetJobDistributionService(c, db)
+	})
+
+	statistik.Get("/gaji", func(c *fiber.Ctx) error {
+		return service.GetSalaryBandsService(c, db)
+	})
+}
@@ -2,33 +2,97 @@ package utils
  
 import ( 
     "github.com/noorfarihaf11/clean-arc/app/model"
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/hex"
+    "os"
     "time" 
  
     "github.com/golang-jwt/jwt/v5" 
+    "github.com/google/uuid"
 ) 
  
-var jwtSecret = []byte("your-secret-key-min-32-characters-long") 
+
+// AccessTokenTTL adalah masa berlaku access token (env ACCESS_TOKEN_TTL, default 15 menit)
+func AccessTokenTTL() time.Duration {
+    return durationFromEnv("ACCESS_TOKEN_TTL", 15*time.Minute)
+}
+
+// RefreshTokenTTL adalah masa berlaku refresh token (env REFRESH_TOKEN_TTL, default 7 hari)
+func RefreshTokenTTL() time.Duration {
+    return durationFromEnv("REFRESH_TOKEN_TTL", 7*24*time.Hour)
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+    if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d > 0 {
+        return d
+    }
+    return fallback
+}
  
-func GenerateToken(user model.User) (string, error) { 
+// GenerateToken membuat access token berumur pendek dengan JTI unik agar bisa dicabut.
+// Token ditandatangani kunci aktif dan header kid menunjuk kunci tersebut.
+func GenerateToken(user model.User) (string, *model.JWTClaims, error) { 
+    cfg, err := currentJWTConfig()
+    if err != nil {
+        return "", nil, err
+    }
+
+    now := time.Now()
     claims := model.JWTClaims{ 
         UserID:   user.ID, 
         Username: user.Username, 
         Role:     user.Role, 
+        Jurusan:  user.Jurusan,
         RegisteredClaims: jwt.RegisteredClaims{ 
-            ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)), 
-            IssuedAt:  jwt.NewNumericDate(time.Now()), 
+            ID:        uuid.NewString(),
+            Issuer:    cfg.issuer,
+            Subject:   user.ID.Hex(),
+            Audience:  cfg.audience,
+            ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL())), 
+            IssuedAt:  jwt.NewNumericDate(now), 
         }, 
     } 
  
-    token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims) 
-    return token.SignedString(jwtSecret) 
+    token := jwt.NewWithClaims(cfg.active.method, claims) 
+    token.Header["kid"] = cfg.active.kid
+    signed, err := token.SignedString(cfg.active.sign) 
+    if err != nil {
+        return "", nil, err
+    }
+    return signed, &claims, nil
 } 
+
+// GenerateRefreshToken membuat refresh token acak; yang disimpan di database hanya hash-nya
+func GenerateRefreshToken() (token string, hash string, err error) {
+    b := make([]byte, 32)
+    if _, err := rand.Read(b); err != nil {
+        return "", "", err
+    }
+    token = base64.RawURLEncoding.EncodeToString(b)
+    return token, HashToken(token), nil
+}
+
+// HashToken menghasilkan hash SHA-256 (hex) dari token
+func HashToken(token string) string {
+    sum := sha256.Sum256([]byte(token))
+    return hex.EncodeToString(sum[:])
+}
  
+// ValidateToken memverifikasi tanda tangan (kid dan algoritma harus cocok), issuer, audience, dan masa berlaku token
 func ValidateToken(tokenString string) (*model.JWTClaims, error) { 
-    token, err := jwt.ParseWithClaims(tokenString, &model.JWTClaims{}, 
-func(token *jwt.Token) (interface{}, error) { 
-        return jwtSecret, nil 
-    }) 
+    cfg, err := currentJWTConfig()
+    if err != nil {
+        return nil, err
+    }
+
+    token, err := jwt.ParseWithClaims(tokenString, &model.JWTClaims{}, cfg.keyFunc,
+        jwt.WithValidMethods(cfg.methods),
+        jwt.WithIssuer(cfg.issuer),
+        jwt.WithAudience(cfg.audience...),
+        jwt.WithExpirationRequired(),
+    ) 
  
     if err != nil { 
         return nil, err 
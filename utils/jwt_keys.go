@@ -0,0 +1,283 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+)
+
+// Konfigurasi JWT dibaca dari environment:
+//
+//	JWT_SECRET                secret HMAC (HS256), minimal 32 karakter
+//	JWT_PRIVATE_KEY_FILE      file PEM private key RSA (RS256) atau Ed25519 (EdDSA); jika diisi, dipakai menggantikan JWT_SECRET
+//	JWT_PREVIOUS_SECRETS      secret HMAC lama, dipisah koma; hanya untuk verifikasi selama rotasi
+//	JWT_PREVIOUS_KEY_FILES    file PEM (private atau public key) lama, dipisah koma; hanya untuk verifikasi selama rotasi
+//	JWT_ISSUER                nilai claim iss (default clean-arc)
+//	JWT_AUDIENCE              nilai claim aud, dipisah koma (default clean-arc-api)
+//
+// kid setiap kunci diturunkan dari fingerprint kuncinya, sehingga token lama tetap
+// menunjuk kunci yang benar setelah kunci aktif diganti.
+const (
+	defaultJWTIssuer   = "clean-arc"
+	defaultJWTAudience = "clean-arc-api"
+	minJWTSecretLength = 32
+)
+
+// secret yang pernah ter-commit ke repository dan sudah bocor, tidak boleh dipakai lagi
+var leakedJWTSecrets = map[string]bool{
+	"your-secret-key-min-32-characters-long":         true,
+	"dev-only-secret-ganti-di-production-0123456789": true,
+}
+
+type jwtKey struct {
+	kid    string
+	method jwt.SigningMethod
+	sign   interface{}
+	verify interface{}
+}
+
+type jwtConfig struct {
+	issuer   string
+	audience []string
+	active   *jwtKey
+	keys     map[string]*jwtKey
+	methods  []string
+}
+
+var (
+	jwtMu  sync.RWMutex
+	jwtCfg *jwtConfig
+)
+
+// LoadJWTConfig memuat kunci penandatangan, issuer, dan audience dari environment.
+// Dipanggil sekali saat startup; error berarti konfigurasi JWT tidak bisa dipakai.
+func LoadJWTConfig() error {
+	cfg, err := loadJWTConfig()
+	if err != nil {
+		return err
+	}
+
+	jwtMu.Lock()
+	jwtCfg = cfg
+	jwtMu.Unlock()
+	return nil
+}
+
+func currentJWTConfig() (*jwtConfig, error) {
+	jwtMu.RLock()
+	defer jwtMu.RUnlock()
+	if jwtCfg == nil {
+		return nil, errors.New("konfigurasi JWT belum dimuat")
+	}
+	return jwtCfg, nil
+}
+
+func loadJWTConfig() (*jwtConfig, error) {
+	cfg := &jwtConfig{
+		issuer:   strings.TrimSpace(os.Getenv("JWT_ISSUER")),
+		audience: splitEnvList(os.Getenv("JWT_AUDIENCE")),
+		keys:     map[string]*jwtKey{},
+	}
+	if cfg.issuer == "" {
+		cfg.issuer = defaultJWTIssuer
+	}
+	if len(cfg.audience) == 0 {
+		cfg.audience = []string{defaultJWTAudience}
+	}
+
+	var err error
+	if path := strings.TrimSpace(os.Getenv("JWT_PRIVATE_KEY_FILE")); path != "" {
+		cfg.active, err = jwtKeyFromFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.active.sign == nil {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY_FILE %s bukan private key", path)
+		}
+	} else {
+		cfg.active, err = hmacJWTKey(os.Getenv("JWT_SECRET"))
+		if err != nil {
+			return nil, fmt.Errorf("JWT_SECRET: %v", err)
+		}
+	}
+	cfg.add(cfg.active)
+
+	for _, secret := range splitEnvList(os.Getenv("JWT_PREVIOUS_SECRETS")) {
+		key, err := hmacJWTKey(secret)
+		if err != nil {
+			return nil, fmt.Errorf("JWT_PREVIOUS_SECRETS: %v", err)
+		}
+		key.sign = nil
+		cfg.add(key)
+	}
+	for _, path := range splitEnvList(os.Getenv("JWT_PREVIOUS_KEY_FILES")) {
+		key, err := jwtKeyFromFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key.sign = nil
+		cfg.add(key)
+	}
+
+	return cfg, nil
+}
+
+// add mendaftarkan kunci untuk verifikasi; kunci aktif tidak ditimpa kunci lama dengan kid sama
+func (cfg *jwtConfig) add(key *jwtKey) {
+	if _, exists := cfg.keys[key.kid]; exists {
+		return
+	}
+	cfg.keys[key.kid] = key
+	for _, m := range cfg.methods {
+		if m == key.method.Alg() {
+			return
+		}
+	}
+	cfg.methods = append(cfg.methods, key.method.Alg())
+}
+
+// keyFunc memilih kunci berdasarkan kid dan memastikan algoritma token sama dengan algoritma kunci
+func (cfg *jwtConfig) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("token tidak memiliki kid")
+	}
+	key, ok := cfg.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("kid %q tidak dikenal", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("algoritma %s tidak sesuai dengan kunci %s", token.Method.Alg(), kid)
+	}
+	return key.verify, nil
+}
+
+func hmacJWTKey(secret string) (*jwtKey, error) {
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return nil, errors.New("secret wajib diisi")
+	}
+	if leakedJWTSecrets[secret] {
+		return nil, errors.New("secret yang pernah ter-commit tidak boleh dipakai, buat secret baru")
+	}
+	if len(secret) < minJWTSecretLength {
+		return nil, fmt.Errorf("secret minimal %d karakter", minJWTSecretLength)
+	}
+
+	sum := sha256.Sum256([]byte(secret))
+	return &jwtKey{
+		kid:    "hs-" + hex.EncodeToString(sum[:8]),
+		method: jwt.SigningMethodHS256,
+		sign:   []byte(secret),
+		verify: []byte(secret),
+	}, nil
+}
+
+// jwtKeyFromFile membaca private atau public key RSA/Ed25519 dalam format PEM
+func jwtKeyFromFile(path string) (*jwtKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca kunci JWT %s: %v", path, err)
+	}
+	key, err := parseJWTKeyPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("kunci JWT %s: %v", path, err)
+	}
+	return key, nil
+}
+
+func parseJWTKeyPEM(data []byte) (*jwtKey, error) {
+	if priv, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		return asymmetricJWTKey(jwt.SigningMethodRS256, priv, &priv.PublicKey)
+	}
+	if pub, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return asymmetricJWTKey(jwt.SigningMethodRS256, nil, pub)
+	}
+	if priv, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+		edPriv, ok := priv.(ed25519.PrivateKey)
+		if !ok {
+			return nil, errors.New("private key EdDSA harus Ed25519")
+		}
+		return asymmetricJWTKey(jwt.SigningMethodEdDSA, edPriv, edPriv.Public())
+	}
+	if pub, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+		return asymmetricJWTKey(jwt.SigningMethodEdDSA, nil, pub)
+	}
+	return nil, errors.New("format tidak dikenali, gunakan PEM RSA atau Ed25519")
+}
+
+func asymmetricJWTKey(method jwt.SigningMethod, priv, pub interface{}) (*jwtKey, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(der)
+
+	return &jwtKey{
+		kid:    base64.RawURLEncoding.EncodeToString(sum[:12]),
+		method: method,
+		sign:   priv,
+		verify: pub,
+	}, nil
+}
+
+func splitEnvList(v string) []string {
+	var out []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// JWKS mengembalikan public key yang dipakai untuk verifikasi token, termasuk kunci
+// lama yang masih dalam masa rotasi. Secret HMAC tidak pernah ikut dipublikasikan.
+func JWKS() (model.JWKSet, error) {
+	cfg, err := currentJWTConfig()
+	if err != nil {
+		return model.JWKSet{}, err
+	}
+
+	set := model.JWKSet{Keys: []model.JWK{}}
+	// kunci aktif selalu di urutan pertama
+	var previous []*jwtKey
+	for kid, key := range cfg.keys {
+		if kid != cfg.active.kid {
+			previous = append(previous, key)
+		}
+	}
+	sort.Slice(previous, func(i, j int) bool { return previous[i].kid < previous[j].kid })
+	ordered := append([]*jwtKey{cfg.active}, previous...)
+
+	for _, key := range ordered {
+		jwk := model.JWK{Kid: key.kid, Use: "sig", Alg: key.method.Alg()}
+		switch pub := key.verify.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set, nil
+}
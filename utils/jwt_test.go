@@ -0,0 +1,207 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/noorfarihaf11/clean-arc/app/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	testSecretA = "secret-a-untuk-test-minimal-32-karakter"
+	testSecretB = "secret-b-untuk-test-minimal-32-karakter"
+)
+
+var testUser = model.User{ID: primitive.NewObjectID(), Username: "budi", Role: "user"}
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func loadTestConfig(t *testing.T, env map[string]string) {
+	t.Helper()
+	for _, k := range []string{"JWT_SECRET", "JWT_PRIVATE_KEY_FILE", "JWT_PREVIOUS_SECRETS", "JWT_PREVIOUS_KEY_FILES", "JWT_ISSUER", "JWT_AUDIENCE"} {
+		t.Setenv(k, env[k])
+	}
+	if err := LoadJWTConfig(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLoadJWTConfig_RejectsWeakSecret(t *testing.T) {
+	for _, secret := range []string{"", "pendek", "your-secret-key-min-32-characters-long", "dev-only-secret-ganti-di-production-0123456789"} {
+		t.Setenv("JWT_PRIVATE_KEY_FILE", "")
+		t.Setenv("JWT_SECRET", secret)
+		if err := LoadJWTConfig(); err == nil {
+			t.Errorf("expected error for secret %q", secret)
+		}
+	}
+}
+
+func TestToken_HMACRotation(t *testing.T) {
+	loadTestConfig(t, map[string]string{"JWT_SECRET": testSecretA})
+	oldToken, _, err := GenerateToken(testUser)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// kunci diganti, kunci lama masih dipakai untuk verifikasi
+	loadTestConfig(t, map[string]string{"JWT_SECRET": testSecretB, "JWT_PREVIOUS_SECRETS": testSecretA})
+	claims, err := ValidateToken(oldToken)
+	if err != nil {
+		t.Fatalf("old token should still validate: %v", err)
+	}
+	if claims.UserID != testUser.ID || claims.Issuer != defaultJWTIssuer {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+
+	// masa rotasi selesai
+	loadTestConfig(t, map[string]string{"JWT_SECRET": testSecretB})
+	if _, err := ValidateToken(oldToken); err == nil {
+		t.Error("expected old token to be rejected after rotation")
+	}
+}
+
+func TestToken_IssuerAudience(t *testing.T) {
+	loadTestConfig(t, map[string]string{"JWT_SECRET": testSecretA, "JWT_AUDIENCE": "service-lain"})
+	token, _, _ := GenerateToken(testUser)
+
+	loadTestConfig(t, map[string]string{"JWT_SECRET": testSecretA})
+	if _, err := ValidateToken(token); err == nil {
+		t.Error("expected audience mismatch to be rejected")
+	}
+
+	loadTestConfig(t, map[string]string{"JWT_SECRET": testSecretA, "JWT_AUDIENCE": "service-lain", "JWT_ISSUER": "issuer-lain"})
+	if _, err := ValidateToken(token); err == nil {
+		t.Error("expected issuer mismatch to be rejected")
+	}
+}
+
+func TestToken_RS256AndJWKS(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := writePEM(t, "rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(priv))
+	loadTestConfig(t, map[string]string{"JWT_PRIVATE_KEY_FILE": keyFile, "JWT_PREVIOUS_SECRETS": testSecretA})
+
+	token, _, err := GenerateToken(testUser)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := ValidateToken(token); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	set, err := JWKS()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// secret HMAC lama tidak boleh ikut dipublikasikan
+	if len(set.Keys) != 1 || set.Keys[0].Kty != "RSA" || set.Keys[0].Alg != "RS256" || set.Keys[0].E != "AQAB" {
+		t.Errorf("unexpected JWKS: %+v", set)
+	}
+}
+
+func TestToken_EdDSA(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loadTestConfig(t, map[string]string{"JWT_PRIVATE_KEY_FILE": writePEM(t, "ed.pem", "PRIVATE KEY", der)})
+
+	token, _, err := GenerateToken(testUser)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := ValidateToken(token); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	set, _ := JWKS()
+	if len(set.Keys) != 1 || set.Keys[0].Kty != "OKP" || set.Keys[0].Crv != "Ed25519" {
+		t.Errorf("unexpected JWKS: %+v", set)
+	}
+}
+
+func TestValidateToken_RejectsAlgorithmMismatch(t *testing.T) {
+	loadTestConfig(t, map[string]string{"JWT_SECRET": testSecretA})
+	cfg, _ := currentJWTConfig()
+
+	claims := model.JWTClaims{RegisteredClaims: jwt.RegisteredClaims{
+		Issuer:   cfg.issuer,
+		Audience: cfg.audience,
+	}}
+
+	// alg none
+	none := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+	none.Header["kid"] = cfg.active.kid
+	signed, _ := none.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if _, err := ValidateToken(signed); err == nil {
+		t.Error("expected alg none to be rejected")
+	}
+
+	// HS512 dengan kid milik kunci HS256
+	hs512 := jwt.NewWithClaims(jwt.SigningMethodHS512, claims)
+	hs512.Header["kid"] = cfg.active.kid
+	signed, _ = hs512.SignedString([]byte(testSecretA))
+	if _, err := ValidateToken(signed); err == nil {
+		t.Error("expected HS512 to be rejected")
+	}
+
+	// tanpa kid
+	noKid := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, _ = noKid.SignedString([]byte(testSecretA))
+	if _, err := ValidateToken(signed); err == nil {
+		t.Error("expected token without kid to be rejected")
+	}
+}
+
+func TestGenerateRefreshToken(t *testing.T) {
+	token, hash, err := GenerateRefreshToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(token) != 43 {
+		t.Errorf("token length = %d, want 43 (32 byte base64url)", len(token))
+	}
+	if hash != HashToken(token) || hash == token {
+		t.Errorf("hash harus SHA-256 dari token, got %q", hash)
+	}
+
+	other, otherHash, err := GenerateRefreshToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == token || otherHash == hash {
+		t.Error("refresh token harus acak di setiap pemanggilan")
+	}
+}
+
+func TestHashToken(t *testing.T) {
+	// sha256("abc")
+	const want = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if got := HashToken("abc"); got != want {
+		t.Errorf("HashToken(abc) = %s, want %s", got, want)
+	}
+	if HashToken("abc") == HashToken("abd") {
+		t.Error("token berbeda harus menghasilkan hash berbeda")
+	}
+}